@@ -530,6 +530,11 @@ func (p *SearchAssetsParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *SearchAssetsParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // SearchAssets searches for assets based on asset metadata. The method can
 // retrieve all assets or only assets owned by the content owner. This method
 // mimics the functionality of the advanced search feature on the Assets page
@@ -587,6 +592,11 @@ func (p *ListAssetSharesParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListAssetSharesParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListAssetShares either retrieves a list of asset shares the partner owns and
 // that map to a specified asset view ID or it retrieves a list of asset views
 // associated with a specified asset share ID owned by the partner.
@@ -16,24 +16,12 @@ var (
 	ErrInvalidPrivateKey = errors.New("invalid private key")
 )
 
-// ConvertServiceAccountJsonToJWT converts a service account JSON to JWT format.
+// ConvertServiceAccountJsonToJWT converts a service account JSON to JWT format. If conf.Subject is set, the JWT acts
+// as that user through domain-wide delegation.
 func ConvertServiceAccountJsonToJWT(conf *jwt.Config) (string, error) {
-	// Parse the PrivateKey
-	key := conf.PrivateKey
-	block, _ := pem.Decode(key)
-	if block != nil {
-		key = block.Bytes
-	}
-	parsedKey, err := x509.ParsePKCS8PrivateKey(key)
+	parsed, err := parseServiceAccountKey(conf.PrivateKey)
 	if err != nil {
-		parsedKey, err = x509.ParsePKCS1PrivateKey(key)
-		if err != nil {
-			return "", fmt.Errorf("private key should be a PEM or plain PKCS1 or PKCS8; parse error: %v", err)
-		}
-	}
-	parsed, ok := parsedKey.(*rsa.PrivateKey)
-	if !ok {
-		return "", ErrInvalidPrivateKey
+		return "", err
 	}
 
 	// Encode JWT
@@ -43,9 +31,31 @@ func ConvertServiceAccountJsonToJWT(conf *jwt.Config) (string, error) {
 	}
 	claimSet := &jws.ClaimSet{
 		Iss:           conf.Email,
+		Sub:           conf.Subject,
 		Scope:         strings.Join(conf.Scopes, " "),
 		Aud:           conf.TokenURL,
 		PrivateClaims: conf.PrivateClaims,
 	}
 	return jws.Encode(header, claimSet, parsed)
 }
+
+// parseServiceAccountKey parses the RSA private key of a service account, which may be PEM encoded or plain PKCS1 or
+// PKCS8.
+func parseServiceAccountKey(key []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block != nil {
+		key = block.Bytes
+	}
+	parsedKey, err := x509.ParsePKCS8PrivateKey(key)
+	if err != nil {
+		parsedKey, err = x509.ParsePKCS1PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("private key should be a PEM or plain PKCS1 or PKCS8; parse error: %v", err)
+		}
+	}
+	parsed, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+	return parsed, nil
+}
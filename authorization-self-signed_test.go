@@ -0,0 +1,47 @@
+package youtube
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/jws"
+	"golang.org/x/oauth2/jwt"
+)
+
+// newTestKey generates an RSA key and its PKCS8 PEM encoding.
+func newTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestSelfSignedJwtSource(t *testing.T) {
+	key, keyPEM := newTestKey(t)
+	conf := &jwt.Config{Email: "sa@example.iam.gserviceaccount.com", PrivateKey: keyPEM, PrivateKeyID: "kid"}
+
+	s := &SelfSignedJwtSource{Config: conf, Audience: SelfSignedAudienceYouTube, Lifetime: 3 * time.Hour}
+	tok, err := s.Token()
+	require.NoError(t, err)
+	require.NoError(t, jws.Verify(tok.AccessToken, &key.PublicKey))
+
+	claims, err := jws.Decode(tok.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, tok.Expiry.Unix(), claims.Exp, "token expiry should match the JWT")
+	require.LessOrEqual(t, claims.Exp-claims.Iat, int64(MaxSelfSignedJwtLifetime/time.Second), "lifetime should be capped")
+
+	again, err := s.Jwt()
+	require.NoError(t, err)
+	require.Equal(t, tok.AccessToken, again, "JWT should be cached")
+
+	s = &SelfSignedJwtSource{Config: conf, Audience: SelfSignedAudienceYouTube, Lifetime: time.Minute}
+	_, err = s.Jwt()
+	require.ErrorIs(t, err, ErrInvalidRefreshWindow)
+}
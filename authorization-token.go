@@ -1,13 +1,29 @@
 package youtube
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
 const (
 	ExchangeOAuthTokenUrl = "https://oauth2.googleapis.com/token"
+	DeviceCodeUrl         = "https://oauth2.googleapis.com/device/code"
+)
+
+// The endpoints and device polling intervals are variables so that tests can replace them with a local server and
+// short intervals.
+var (
+	tokenUrl      = ExchangeOAuthTokenUrl
+	deviceCodeUrl = DeviceCodeUrl
+
+	// defaultDevicePollInterval is used when the device code response does not specify a polling interval.
+	defaultDevicePollInterval = 5 * time.Second
+	// deviceSlowDownIncrease is added to the polling interval whenever the server responds with slow_down.
+	deviceSlowDownIncrease = 5 * time.Second
 )
 
 // Token details the access and refresh tokens as well as allowed scopes.
@@ -17,6 +33,42 @@ type Token struct {
 	RefreshToken  string `json:"refresh_token"`
 	Scope         string `json:"scope"`
 	TokenType     string `json:"token_type"`
+
+	// IdToken is the OpenID Connect ID token, returned when the openid scope is requested. See IdTokenVerifier.
+	IdToken string `json:"id_token,omitempty"`
+
+	// Expiry is the absolute time at which the access token expires. It is computed from ExpiresInSecs when the
+	// token is received and is zero if the server did not report an expiry.
+	Expiry time.Time `json:"expiry,omitzero"`
+}
+
+// Valid reports whether the token has an access token which has not yet expired.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && !t.ExpiresWithin(0)
+}
+
+// ExpiresWithin reports whether the access token expires within the provided duration from now. A token without an
+// Expiry is never considered to be expiring, while a nil token always is.
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	if t == nil {
+		return true
+	}
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return !time.Now().Add(d).Before(t.Expiry)
+}
+
+// setExpiry computes the absolute Expiry from ExpiresInSecs, relative to the provided time.
+func (t *Token) setExpiry(now time.Time) {
+	if t.ExpiresInSecs > 0 {
+		t.Expiry = now.Add(time.Duration(t.ExpiresInSecs) * time.Second)
+	}
+}
+
+// Scopes returns the scopes granted to the token.
+func (t *Token) Scopes() []Scope {
+	return ParseScopes(t.Scope)
 }
 
 // ExchangeAuthToken exchanges an authorization token retrieved through YouTube's OAUTH flow for a Token which contains
@@ -30,44 +82,180 @@ func ExchangeAuthToken(clientId, clientSecret, code, redirect string, timeout ti
 	vals.Add("grant_type", "authorization_code")
 	vals.Add("code", code)
 	vals.Add("redirect_uri", redirect)
+	return requestToken(vals, timeout)
+}
+
+// ExchangeAuthTokenWithVerifier exchanges an authorization code obtained with a PKCE code challenge for a Token. The
+// code verifier used to derive the challenge must be provided. The client secret is optional, as installed
+// applications which use PKCE cannot keep it confidential; it is omitted from the request if empty.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/native-app#exchange-authorization-code
+func ExchangeAuthTokenWithVerifier(clientId, clientSecret, code, codeVerifier, redirect string, timeout time.Duration) (*Token, error) {
+	if !isValidPKCEValue(codeVerifier) {
+		return nil, ErrInvalidCodeVerifier
+	}
+	vals := url.Values{}
+	vals.Add("client_id", clientId)
+	if clientSecret != "" {
+		vals.Add("client_secret", clientSecret)
+	}
+	vals.Add("grant_type", "authorization_code")
+	vals.Add("code", code)
+	vals.Add("code_verifier", codeVerifier)
+	vals.Add("redirect_uri", redirect)
+	return requestToken(vals, timeout)
+}
+
+// ExchangeJwtToken exchanges a Jwt token for an access token. The JWT token can be generated through
+// Use ConvertServiceAccountJsonToJWT to convert. Then, pass the access token to requests that require it. Note the
+// timeout to make sure that a timed-out access token is not used. If it is close to timing out, use
+// ConvertServiceAccountJsonToJWT to generate a new one.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/service-account#httprest
+func ExchangeJwtToken(jwt string, timeout time.Duration) (*Token, error) {
+	vals := url.Values{}
+	vals.Add("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	vals.Add("assertion", jwt)
+	return requestToken(vals, timeout)
+}
+
+// RefreshAuthToken exchanges a refresh token for a new access token. Google usually omits the refresh token from the
+// response, in which case the provided refresh token is carried forward onto the returned Token.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/web-server#offline
+func RefreshAuthToken(clientId, clientSecret, refreshToken string, timeout time.Duration) (*Token, error) {
+	vals := url.Values{}
+	vals.Add("client_id", clientId)
+	vals.Add("client_secret", clientSecret)
+	vals.Add("grant_type", "refresh_token")
+	vals.Add("refresh_token", refreshToken)
+
+	t, err := requestToken(vals, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = refreshToken
+	}
+	return t, nil
+}
+
+// DeviceCode is the response to a device authorization request. Display UserCode and VerificationUrl to the user,
+// then pass the DeviceCode to PollDeviceToken.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationUrl string `json:"verification_url"`
+	ExpiresInSecs   int    `json:"expires_in"`
+	IntervalSecs    int    `json:"interval"`
+
+	// Expiry is the absolute time at which the device and user codes expire.
+	Expiry time.Time `json:"expiry,omitzero"`
+}
+
+// RequestDeviceCode starts the OAuth 2.0 device authorization flow for devices with limited input capabilities, such
+// as tooling running on headless servers. Only a subset of scopes is allowed in this flow.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/limited-input-device
+func RequestDeviceCode(clientId string, scopes []Scope, timeout time.Duration) (*DeviceCode, error) {
+	if clientId == "" {
+		return nil, ErrMissingClientId
+	}
+	if len(scopes) == 0 {
+		return nil, ErrMissingScopes
+	}
+	vals := url.Values{}
+	vals.Add("client_id", clientId)
+	vals.Add("scope", strings.Join(OAuthOptions{Scopes: scopes}.convertScopes(), " "))
 
 	runner := &UnauthenticatedRunner{
 		Timeout: timeout,
 	}
+	now := time.Now()
 	res, err := runner.Run(&Request{
-		Method:  http.MethodPost,
-		Url:     ExchangeOAuthTokenUrl,
-		Params:  vals,
+		Method: http.MethodPost,
+		Url:    deviceCodeUrl,
+		Params: vals,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	var t Token
-	if err := DecodeResponse(res, &t); err != nil {
+	var d DeviceCode
+	if err := DecodeResponse(res, &d); err != nil {
 		return nil, err
 	}
-	return &t, nil
+	if d.ExpiresInSecs > 0 {
+		d.Expiry = now.Add(time.Duration(d.ExpiresInSecs) * time.Second)
+	}
+	return &d, nil
 }
 
-// ExchangeJwtToken exchanges a Jwt token for an access token. The JWT token can be generated through
-// Use ConvertServiceAccountJsonToJWT to convert. Then, pass the access token to requests that require it. Note the
-// timeout to make sure that a timed-out access token is not used. If it is close to timing out, use
-// ConvertServiceAccountJsonToJWT to generate a new one.
+// PollDeviceToken polls the token endpoint at the interval specified by the device code until the user grants or
+// denies access. A slow_down response increases the interval. If the user denies access, ErrAccessDenied is returned;
+// if the device code expires first, ErrExpiredToken is returned. Cancelling the context stops polling and returns the
+// context's error. A nil or empty code returns ErrMissingDeviceCode.
 //
-// @see https://developers.google.com/identity/protocols/oauth2/service-account#httprest
-func ExchangeJwtToken(jwt string, timeout time.Duration) (*Token, error) {
+// @see https://developers.google.com/identity/protocols/oauth2/limited-input-device#step-4:-poll-googles-authorization-server
+func PollDeviceToken(ctx context.Context, clientId, clientSecret string, code *DeviceCode, timeout time.Duration) (*Token, error) {
+	if code == nil || code.DeviceCode == "" {
+		return nil, ErrMissingDeviceCode
+	}
 	vals := url.Values{}
-	vals.Add("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
-	vals.Add("assertion", jwt)
+	vals.Add("client_id", clientId)
+	vals.Add("client_secret", clientSecret)
+	vals.Add("device_code", code.DeviceCode)
+	vals.Add("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+
+	interval := time.Duration(code.IntervalSecs) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
 
+	for {
+		if !code.Expiry.IsZero() && time.Now().After(code.Expiry) {
+			return nil, ErrExpiredToken
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		t, err := requestToken(vals, timeout)
+		if err == nil {
+			return t, nil
+		}
+		var e Error
+		if !errors.As(err, &e) {
+			return nil, err
+		}
+		switch e.ErrorType {
+		case ErrTypeAuthorizationPending:
+		case ErrTypeSlowDown:
+			interval += deviceSlowDownIncrease
+		case ErrTypeAccessDenied:
+			return nil, ErrAccessDenied
+		case ErrTypeExpiredToken:
+			return nil, ErrExpiredToken
+		default:
+			return nil, err
+		}
+	}
+}
+
+// requestToken posts the provided values to the token endpoint and decodes the resulting Token, computing its Expiry.
+func requestToken(vals url.Values, timeout time.Duration) (*Token, error) {
 	runner := &UnauthenticatedRunner{
 		Timeout: timeout,
 	}
+	now := time.Now()
 	res, err := runner.Run(&Request{
-		Method:  http.MethodPost,
-		Url:     ExchangeOAuthTokenUrl,
-		Params:  vals,
+		Method: http.MethodPost,
+		Url:    tokenUrl,
+		Params: vals,
 	})
 	if err != nil {
 		return nil, err
@@ -77,5 +265,6 @@ func ExchangeJwtToken(jwt string, timeout time.Duration) (*Token, error) {
 	if err := DecodeResponse(res, &t); err != nil {
 		return nil, err
 	}
+	t.setExpiry(now)
 	return &t, nil
-}
\ No newline at end of file
+}
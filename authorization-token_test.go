@@ -0,0 +1,229 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withTokenServer points the token endpoint at a local server running h for the duration of the test.
+func withTokenServer(t *testing.T, h http.HandlerFunc) {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	old := tokenUrl
+	tokenUrl = srv.URL
+	t.Cleanup(func() { tokenUrl = old })
+}
+
+func writeJson(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestRefreshAuthToken(t *testing.T) {
+	var newRefresh, method string
+	var got url.Values
+	withTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		method, got = r.Method, r.URL.Query()
+		writeJson(w, http.StatusOK, Token{
+			AccessToken:   "access",
+			ExpiresInSecs: 3600,
+			RefreshToken:  newRefresh,
+			TokenType:     "Bearer",
+		})
+	})
+
+	// Google usually omits the refresh token, in which case the one which was used is carried forward.
+	before := time.Now()
+	tok, err := RefreshAuthToken("client", "secret", "refresh", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, method)
+	require.Equal(t, "refresh_token", got.Get("grant_type"))
+	require.Equal(t, "client", got.Get("client_id"))
+	require.Equal(t, "secret", got.Get("client_secret"))
+	require.Equal(t, "refresh", got.Get("refresh_token"))
+	require.Equal(t, "access", tok.AccessToken)
+	require.Equal(t, "refresh", tok.RefreshToken)
+	require.False(t, tok.Expiry.Before(before.Add(time.Hour)))
+	require.False(t, tok.Expiry.After(time.Now().Add(time.Hour)))
+
+	// A rotated refresh token replaces the old one.
+	newRefresh = "rotated"
+	tok, err = RefreshAuthToken("client", "secret", "refresh", time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "rotated", tok.RefreshToken)
+}
+
+func TestRefreshAuthToken_Error(t *testing.T) {
+	withTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, http.StatusBadRequest, map[string]string{
+			"error":             "invalid_grant",
+			"error_description": "Token has been expired or revoked.",
+		})
+	})
+
+	_, err := RefreshAuthToken("client", "secret", "refresh", time.Second)
+	var e Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, ErrTypeInvalidGrant, e.ErrorType)
+	require.Equal(t, http.StatusBadRequest, e.StatusCode)
+}
+
+func TestToken_ExpiresWithin(t *testing.T) {
+	now := time.Now()
+
+	var tok *Token
+	require.True(t, tok.ExpiresWithin(0))
+	require.False(t, tok.Valid())
+
+	tok = &Token{AccessToken: "access"}
+	require.False(t, tok.ExpiresWithin(time.Hour), "a token without an expiry never expires")
+	require.True(t, tok.Valid())
+
+	tok.setExpiry(now)
+	require.True(t, tok.Expiry.IsZero(), "no expiry is set without expires_in")
+
+	tok.ExpiresInSecs = 600
+	tok.setExpiry(now)
+	require.Equal(t, now.Add(10*time.Minute), tok.Expiry)
+	require.False(t, tok.ExpiresWithin(time.Minute))
+	require.True(t, tok.ExpiresWithin(10*time.Minute))
+	require.True(t, tok.Valid())
+
+	tok.Expiry = now.Add(-time.Second)
+	require.True(t, tok.ExpiresWithin(0))
+	require.False(t, tok.Valid())
+}
+
+// withDeviceServer points the device code and token endpoints at a local server running h and shortens the polling
+// intervals for the duration of the test.
+func withDeviceServer(t *testing.T, h http.HandlerFunc) {
+	t.Helper()
+	withTokenServer(t, h)
+
+	oldCode, oldInterval, oldIncrease := deviceCodeUrl, defaultDevicePollInterval, deviceSlowDownIncrease
+	deviceCodeUrl = tokenUrl
+	defaultDevicePollInterval = time.Millisecond
+	deviceSlowDownIncrease = 50 * time.Millisecond
+	t.Cleanup(func() {
+		deviceCodeUrl, defaultDevicePollInterval, deviceSlowDownIncrease = oldCode, oldInterval, oldIncrease
+	})
+}
+
+func TestRequestDeviceCode(t *testing.T) {
+	var got url.Values
+	withDeviceServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		writeJson(w, http.StatusOK, DeviceCode{
+			DeviceCode:      "device",
+			UserCode:        "ABC-DEF",
+			VerificationUrl: "https://www.google.com/device",
+			ExpiresInSecs:   1800,
+			IntervalSecs:    5,
+		})
+	})
+
+	_, err := RequestDeviceCode("", []Scope{ScopeReadOnly}, time.Second)
+	require.ErrorIs(t, err, ErrMissingClientId)
+	_, err = RequestDeviceCode("client", nil, time.Second)
+	require.ErrorIs(t, err, ErrMissingScopes)
+
+	code, err := RequestDeviceCode("client", []Scope{ScopeReadOnly}, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "client", got.Get("client_id"))
+	require.Equal(t, string(ScopeReadOnly), got.Get("scope"))
+	require.Equal(t, "ABC-DEF", code.UserCode)
+	require.WithinDuration(t, time.Now().Add(30*time.Minute), code.Expiry, time.Minute)
+}
+
+func TestPollDeviceToken(t *testing.T) {
+	var mu sync.Mutex
+	var calls []time.Time
+	var grants []string
+	responses := []ErrorType{ErrTypeAuthorizationPending, ErrTypeSlowDown, ""}
+	withDeviceServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		res := responses[min(len(calls), len(responses)-1)]
+		calls = append(calls, time.Now())
+		grants = append(grants, r.URL.Query().Get("grant_type"))
+		if res != "" {
+			writeJson(w, http.StatusPreconditionRequired, map[string]string{"error": string(res)})
+			return
+		}
+		writeJson(w, http.StatusOK, Token{AccessToken: "access", RefreshToken: "refresh", ExpiresInSecs: 3600})
+	})
+
+	tok, err := PollDeviceToken(context.Background(), "client", "secret", &DeviceCode{DeviceCode: "device"}, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "access", tok.AccessToken)
+	require.False(t, tok.Expiry.IsZero())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, calls, 3)
+	require.Equal(t, "urn:ietf:params:oauth:grant-type:device_code", grants[0])
+	require.GreaterOrEqual(t, calls[2].Sub(calls[1]), 50*time.Millisecond, "slow_down should increase the interval")
+}
+
+func TestPollDeviceToken_Errors(t *testing.T) {
+	cases := []struct {
+		res  ErrorType
+		want error
+	}{
+		{ErrTypeAccessDenied, ErrAccessDenied},
+		{ErrTypeExpiredToken, ErrExpiredToken},
+	}
+	for _, c := range cases {
+		t.Run(string(c.res), func(t *testing.T) {
+			withDeviceServer(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJson(w, http.StatusForbidden, map[string]string{"error": string(c.res)})
+			})
+			_, err := PollDeviceToken(context.Background(), "client", "secret", &DeviceCode{DeviceCode: "device"}, time.Second)
+			require.ErrorIs(t, err, c.want)
+		})
+	}
+
+	t.Run("other", func(t *testing.T) {
+		withDeviceServer(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJson(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		})
+		_, err := PollDeviceToken(context.Background(), "client", "secret", &DeviceCode{DeviceCode: "device"}, time.Second)
+		var e Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, ErrorType("invalid_client"), e.ErrorType)
+	})
+
+	t.Run("missing code", func(t *testing.T) {
+		_, err := PollDeviceToken(context.Background(), "client", "secret", nil, time.Second)
+		require.ErrorIs(t, err, ErrMissingDeviceCode)
+		_, err = PollDeviceToken(context.Background(), "client", "secret", &DeviceCode{}, time.Second)
+		require.ErrorIs(t, err, ErrMissingDeviceCode)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		code := &DeviceCode{DeviceCode: "device", Expiry: time.Now().Add(-time.Second)}
+		_, err := PollDeviceToken(context.Background(), "client", "secret", code, time.Second)
+		require.ErrorIs(t, err, ErrExpiredToken)
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		withDeviceServer(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJson(w, http.StatusPreconditionRequired, map[string]string{"error": string(ErrTypeAuthorizationPending)})
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := PollDeviceToken(ctx, "client", "secret", &DeviceCode{DeviceCode: "device"}, time.Second)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,108 @@
+package youtube
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	RevokeTokenUrl = "https://oauth2.googleapis.com/revoke"
+	TokenInfoUrl   = "https://oauth2.googleapis.com/tokeninfo"
+)
+
+// TokenInfo is the introspection result for an access token as returned by Google's tokeninfo endpoint. Numeric and
+// boolean values are reported by Google as strings, so use the accessor methods to read them.
+type TokenInfo struct {
+	// Audience is the client id the token was issued to.
+	Audience string `json:"aud"`
+	// AuthorizedParty is the client id of the party the token was issued to. Usually identical to Audience.
+	AuthorizedParty string `json:"azp"`
+	// Subject is the unique Google account id of the user.
+	Subject string `json:"sub"`
+	// Scope is the space separated list of scopes which were granted.
+	Scope string `json:"scope"`
+	// Exp is the expiry of the token in seconds since the unix epoch.
+	Exp string `json:"exp"`
+	// ExpiresIn is the number of seconds remaining before the token expires.
+	ExpiresIn string `json:"expires_in"`
+	// Email is the email of the user. Only present if the email scope was granted.
+	Email string `json:"email"`
+	// EmailVerified is "true" if Google has verified the email.
+	EmailVerified string `json:"email_verified"`
+	// AccessType is either "online" or "offline".
+	AccessType string `json:"access_type"`
+}
+
+// Expiry returns the absolute expiry of the token, or the zero time if it could not be parsed.
+func (i *TokenInfo) Expiry() time.Time {
+	secs, err := strconv.ParseInt(i.Exp, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
+// IsEmailVerified returns true if Google has verified the email of the user.
+func (i *TokenInfo) IsEmailVerified() bool {
+	return i.EmailVerified == "true"
+}
+
+// Scopes returns the granted scopes.
+func (i *TokenInfo) Scopes() []Scope {
+	return ParseScopes(i.Scope)
+}
+
+// MissingScopes returns the scopes in required which were not granted to the token.
+func (i *TokenInfo) MissingScopes(required []Scope) []Scope {
+	return MissingScopes(i.Scopes(), required)
+}
+
+// GetTokenInfo introspects the provided access token, returning its audience, granted scopes, expiry and email.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/web-server#callinganapi
+func GetTokenInfo(accessToken string, timeout time.Duration) (*TokenInfo, error) {
+	vals := url.Values{}
+	vals.Add("access_token", accessToken)
+
+	runner := &UnauthenticatedRunner{
+		Timeout: timeout,
+	}
+	res, err := runner.Run(&Request{
+		Method: http.MethodGet,
+		Url:    TokenInfoUrl,
+		Params: vals,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var i TokenInfo
+	if err := DecodeResponse(res, &i); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// RevokeToken revokes an access or refresh token. Revoking a refresh token also revokes all access tokens issued from
+// it, effectively disconnecting the user's account from the application.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/web-server#tokenrevoke
+func RevokeToken(token string, timeout time.Duration) error {
+	vals := url.Values{}
+	vals.Add("token", token)
+
+	runner := &UnauthenticatedRunner{
+		Timeout: timeout,
+	}
+	res, err := runner.Run(&Request{
+		Method: http.MethodPost,
+		Url:    RevokeTokenUrl,
+		Params: vals,
+	})
+	if err != nil {
+		return err
+	}
+	return DecodeResponse(res, nil)
+}
@@ -0,0 +1,200 @@
+package youtube
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultBulkChunkSize is the number of ids sent per request. The API caps most id lists at 50.
+	DefaultBulkChunkSize = 50
+
+	// DefaultBulkConcurrency is the number of chunks fetched at once.
+	DefaultBulkConcurrency = 4
+)
+
+// BulkOptions configure the bulk lookup helpers.
+type BulkOptions struct {
+	// ChunkSize is the number of ids sent per request. Defaults to DefaultBulkChunkSize.
+	ChunkSize int
+
+	// Concurrency is the number of requests made at once. Defaults to DefaultBulkConcurrency.
+	Concurrency int
+}
+
+// BulkResult is the result of a bulk lookup.
+type BulkResult[T any] struct {
+	// Items are the resources found, in the order of the ids they were requested with. Duplicate ids yield a single
+	// item.
+	Items []*T
+
+	// ById maps each requested id which was found to its resource.
+	ById map[string]*T
+
+	// Missing are the requested ids which were not found, in input order.
+	Missing []string
+}
+
+// bulkLookup splits ids into chunks, fetches the chunks concurrently and merges the results back into input order.
+// keys returns the ids a resource answers to.
+func bulkLookup[T any](ctx context.Context, ids []string, o BulkOptions, fetch func(chunk []string) iter.Seq2[*T, error], keys func(*T) []string) (*BulkResult[T], error) {
+	size := o.ChunkSize
+	if size <= 0 {
+		size = DefaultBulkChunkSize
+	}
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	// Deduplicate while preserving input order.
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	g, _ := newFanOut(ctx, concurrency)
+	var (
+		mu   sync.Mutex
+		byId = make(map[string]*T, len(unique))
+	)
+	for start := 0; start < len(unique); start += size {
+		chunk := unique[start:min(start+size, len(unique))]
+		g.Go(func() error {
+			for item, err := range fetch(chunk) {
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				for _, k := range keys(item) {
+					if seen[k] {
+						byId[k] = item
+					}
+				}
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	res := &BulkResult[T]{ById: byId}
+	added := make(map[*T]bool, len(byId))
+	for _, id := range unique {
+		item, ok := byId[id]
+		if !ok {
+			res.Missing = append(res.Missing, id)
+			continue
+		}
+		if !added[item] {
+			added[item] = true
+			res.Items = append(res.Items, item)
+		}
+	}
+	return res, nil
+}
+
+// BulkListClaims retrieves any number of claims by id through ListClaims. Only OnBehalfOfContentOwner is used from
+// the params, which may be nil.
+func BulkListClaims(ctx context.Context, runner RequestRunner, ids []string, p *ListClaimsParams, o BulkOptions) (*BulkResult[Claim], error) {
+	var base ListClaimsParams
+	if p != nil {
+		base.OnBehalfOfContentOwner = p.OnBehalfOfContentOwner
+	}
+	return bulkLookup(ctx, ids, o, func(chunk []string) iter.Seq2[*Claim, error] {
+		q := base
+		q.Id = strings.Join(chunk, ",")
+		return ListClaimsIter(runner, &q, PageOptions{})
+	}, func(c *Claim) []string {
+		return []string{c.Id}
+	})
+}
+
+// BulkListReferences retrieves any number of references by id through ListReferences. Only OnBehalfOfContentOwner is
+// used from the params, which may be nil.
+func BulkListReferences(ctx context.Context, runner RequestRunner, ids []string, p *ListReferencesParams, o BulkOptions) (*BulkResult[Reference], error) {
+	var base ListReferencesParams
+	if p != nil {
+		base.OnBehalfOfContentOwner = p.OnBehalfOfContentOwner
+	}
+	return bulkLookup(ctx, ids, o, func(chunk []string) iter.Seq2[*Reference, error] {
+		q := base
+		q.Id = strings.Join(chunk, ",")
+		return ListReferencesIter(runner, &q, PageOptions{})
+	}, func(r *Reference) []string {
+		return []string{r.Id}
+	})
+}
+
+// BulkListVideos retrieves any number of videos by id through ListVideos. Only the parts are used from the params,
+// which may be nil. Without parts, the snippet is requested.
+func BulkListVideos(ctx context.Context, runner RequestRunner, ids []string, p *ListVideoParams, o BulkOptions) (*BulkResult[Video], error) {
+	base := ListVideoParams{
+		Parts: []ListVideoParamsPart{ListVideoParamsPartSnippet},
+	}
+	if p != nil && len(p.Parts) > 0 {
+		base.Parts = p.Parts
+	}
+	return bulkLookup(ctx, ids, o, func(chunk []string) iter.Seq2[*Video, error] {
+		q := base
+		q.Ids = chunk
+		return ListVideosIter(runner, &q, PageOptions{})
+	}, func(v *Video) []string {
+		return []string{v.Id}
+	})
+}
+
+// BulkListChannels retrieves any number of channels by id through ListChannels. The ids and filters of the options are
+// ignored; the parts and optional params are used. The options may be nil. Without parts, the snippet is requested.
+func BulkListChannels(ctx context.Context, runner RequestRunner, ids []string, opts *ListChannelsOpts, o BulkOptions) (*BulkResult[Channel], error) {
+	base := ListChannelsOpts{
+		Parts: []ChannelPart{ChannelPartSnippet},
+	}
+	if opts != nil {
+		if len(opts.Parts) > 0 {
+			base.Parts = opts.Parts
+		}
+		base.HL = opts.HL
+		base.MaxResults = opts.MaxResults
+		base.OnBehalfOfContentOwner = opts.OnBehalfOfContentOwner
+	}
+	return bulkLookup(ctx, ids, o, func(chunk []string) iter.Seq2[*Channel, error] {
+		q := base
+		q.Id = chunk
+		return ListChannelsIter(runner, &q, PageOptions{})
+	}, func(c *Channel) []string {
+		return []string{c.Id}
+	})
+}
+
+// BulkSearchAssetsByIsrc searches assets for any number of ISRCs through SearchAssets. The remaining params, such as
+// the type or ownership restriction, are applied to every chunk. An asset is reported under each requested ISRC it
+// carries.
+func BulkSearchAssetsByIsrc(ctx context.Context, runner RequestRunner, isrcs []string, p *SearchAssetsParams, o BulkOptions) (*BulkResult[AssetSnippet], error) {
+	var base SearchAssetsParams
+	if p != nil {
+		base = *p
+		base.PageToken = ""
+	}
+	return bulkLookup(ctx, isrcs, o, func(chunk []string) iter.Seq2[*AssetSnippet, error] {
+		q := base
+		q.Isrcs = strings.Join(chunk, ",")
+		return SearchAssetsIter(runner, &q, PageOptions{})
+	}, func(a *AssetSnippet) []string {
+		keys := append([]string{}, a.Isrcs...)
+		if a.Isrc != "" {
+			keys = append(keys, a.Isrc)
+		}
+		return keys
+	})
+}
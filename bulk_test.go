@@ -0,0 +1,67 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVideosRunner answers videos.list with a video for every requested id which is not missing.
+type fakeVideosRunner struct {
+	missing map[string]bool
+
+	mu     sync.Mutex
+	chunks [][]string
+	parts  []string
+}
+
+func (r *fakeVideosRunner) Run(req *Request) (*http.Response, error) {
+	ids := strings.Split(req.Params.Get("id"), ",")
+	r.mu.Lock()
+	r.chunks = append(r.chunks, ids)
+	r.parts = append(r.parts, req.Params.Get("part"))
+	r.mu.Unlock()
+
+	var out ListVideosResponse
+	// Answer in reverse order to check that results are merged back into input order.
+	for i := len(ids) - 1; i >= 0; i-- {
+		if !r.missing[ids[i]] {
+			out.Items = append(out.Items, &Video{Id: ids[i]})
+		}
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+	}, nil
+}
+
+func TestBulkListVideos(t *testing.T) {
+	runner := &fakeVideosRunner{missing: map[string]bool{"c": true}}
+	res, err := BulkListVideos(context.Background(), runner, []string{"a", "b", "c", "a", "d", "e"}, nil, BulkOptions{ChunkSize: 2})
+	require.NoError(t, err)
+
+	require.Len(t, runner.chunks, 3, "5 unique ids in chunks of 2")
+	for _, c := range runner.chunks {
+		require.LessOrEqual(t, len(c), 2)
+	}
+	require.Equal(t, []string{"snippet", "snippet", "snippet"}, runner.parts)
+
+	var ids []string
+	for _, v := range res.Items {
+		ids = append(ids, v.Id)
+	}
+	require.Equal(t, []string{"a", "b", "d", "e"}, ids)
+	require.Equal(t, []string{"c"}, res.Missing)
+	require.Equal(t, "d", res.ById["d"].Id)
+}
@@ -73,6 +73,7 @@ type ListChannelsResponse struct {
 	Etag          string     `json:"etag"`
 	PrevPageToken string     `json:"prevPageToken"`
 	NextPageToken string     `json:"nextPageToken"`
+	PageInfo      *PageInfo  `json:"pageInfo"`
 	Items         []*Channel `json:"items"`
 }
 
@@ -130,6 +131,11 @@ func (o ListChannelsOpts) Values() url.Values {
 	return vals
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (o *ListChannelsOpts) SetPageToken(token string) {
+	o.PageToken = token
+}
+
 // ListChannels retrieves a list of channels that match the provided options.
 // @see https://developers.google.com/youtube/v3/docs/channels/list
 func ListChannels(runner RequestRunner, opts *ListChannelsOpts) (*ListChannelsResponse, error) {
@@ -0,0 +1,239 @@
+package youtube
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCheckpointDir is the directory used by ResumableIter when no CheckpointStore is provided.
+	DefaultCheckpointDir = ".checkpoints"
+)
+
+var (
+	ErrCheckpointMismatch  = errors.New("checkpoint params do not match")
+	ErrInvalidCheckpointId = errors.New("invalid checkpoint id")
+	ErrMissingCheckpoint   = errors.New("missing checkpoint id")
+)
+
+// PageTokenSetter is implemented by the params of every paginated endpoint.
+type PageTokenSetter interface {
+	SetPageToken(token string)
+}
+
+// Checkpoint records the progress of a paginated job.
+type Checkpoint struct {
+	// Params are the JSON encoded params the job was started with.
+	Params json.RawMessage `json:"params"`
+
+	// PageToken is the token of the first page which has not been fully emitted.
+	PageToken string `json:"pageToken"`
+
+	// PageOffset is the number of items of the PageToken page which were already emitted.
+	PageOffset int `json:"pageOffset"`
+
+	// Emitted is the total number of items emitted so far.
+	Emitted int `json:"emitted"`
+
+	// Done is true once the last page was emitted.
+	Done bool `json:"done"`
+
+	// UpdatedAt is the time the checkpoint was last saved.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CheckpointStore persists checkpoints keyed by job id. Load returns ErrNotFound if there is no checkpoint for the id.
+type CheckpointStore interface {
+	Load(id string) (*Checkpoint, error)
+	Save(id string, c *Checkpoint) error
+	Delete(id string) error
+}
+
+// FileCheckpointStore stores each checkpoint as a JSON file named after the job id in Dir. Ids containing path
+// separators or ".." are rejected with ErrInvalidCheckpointId so that a checkpoint is never written outside Dir.
+type FileCheckpointStore struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+func (s *FileCheckpointStore) path(id string) (string, error) {
+	if !isValidFileId(id) {
+		return "", ErrInvalidCheckpointId
+	}
+	return filepath.Join(s.Dir, id+".checkpoint.json"), nil
+}
+
+func (s *FileCheckpointStore) Load(id string) (*Checkpoint, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c Checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (s *FileCheckpointStore) Save(id string, c *Checkpoint) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(path, writeBytes(data))
+}
+
+func (s *FileCheckpointStore) Delete(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// isValidFileId reports whether id can be used as a file name without escaping its directory.
+func isValidFileId(id string) bool {
+	return id != "" && id != "." && !strings.Contains(id, "..") && !strings.ContainsAny(id, `/\`)
+}
+
+// CheckpointOptions configure ResumableIter.
+type CheckpointOptions struct {
+	// Id identifies the job. A restarted job with the same id resumes from its checkpoint. This is required.
+	Id string
+
+	// Store persists the checkpoint. Defaults to a FileCheckpointStore in DefaultCheckpointDir.
+	Store CheckpointStore
+
+	// EveryItem saves the checkpoint after every item instead of every page, so that a job restarted after a crash
+	// emits at most the one item it was processing again, at the cost of a store write per item.
+	EveryItem bool
+}
+
+// ResumableIter iterates over every item of a paginated endpoint, persisting a checkpoint after each page. A restarted
+// job resumes from the last committed page and skips the items of that page which were already emitted when the
+// consumer stopped iterating.
+//
+// A crash cannot record how far into a page the job got, so by default the items of a page interrupted by a crash are
+// emitted again and consumers should be idempotent per page. Set EveryItem to narrow this to the single item being
+// processed.
+//
+// Once the last page is emitted the checkpoint is marked done and further runs emit nothing; delete the checkpoint to
+// start over. If the params differ from those the checkpoint was created with, ErrCheckpointMismatch is returned.
+//
+// pages is the page iterator of the endpoint, e.g.
+//
+//	for claim, err := range ResumableIter(runner, params, SearchClaimsPages, CheckpointOptions{Id: "claims-export"}) {
+//	    ...
+//	}
+func ResumableIter[P any, T any, PP interface {
+	*P
+	PageTokenSetter
+}](runner RequestRunner, p PP, pages func(RequestRunner, PP, PageOptions) iter.Seq2[*Page[T], error], o CheckpointOptions) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		if o.Id == "" {
+			yield(nil, ErrMissingCheckpoint)
+			return
+		}
+		store := o.Store
+		if store == nil {
+			store = &FileCheckpointStore{Dir: DefaultCheckpointDir}
+		}
+		params, err := json.Marshal(p)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		// Without a checkpoint, iteration starts from the page token of the params.
+		q := *p
+		cp, err := store.Load(o.Id)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			cp = &Checkpoint{Params: params}
+		case err != nil:
+			yield(nil, err)
+			return
+		case !bytes.Equal(cp.Params, params):
+			yield(nil, ErrCheckpointMismatch)
+			return
+		default:
+			PP(&q).SetPageToken(cp.PageToken)
+		}
+		if cp.Done {
+			return
+		}
+		skip := cp.PageOffset
+
+		save := func() error {
+			cp.UpdatedAt = time.Now()
+			return store.Save(o.Id, cp)
+		}
+
+		for page, err := range pages(runner, PP(&q), PageOptions{}) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for i, item := range page.Items {
+				if i < skip {
+					continue
+				}
+				cp.Emitted++
+				if !yield(item, nil) {
+					// The consumer stopped; record how far into the page it got. There is nowhere to report a
+					// failure to save at this point.
+					cp.PageToken = page.PageToken
+					cp.PageOffset = i + 1
+					save()
+					return
+				}
+				if o.EveryItem {
+					cp.PageToken = page.PageToken
+					cp.PageOffset = i + 1
+					if err := save(); err != nil {
+						yield(nil, err)
+						return
+					}
+				}
+			}
+			skip = 0
+			cp.PageToken = page.NextPageToken
+			cp.PageOffset = 0
+			cp.Done = page.NextPageToken == ""
+			if err := save(); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package youtube
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumableIter(t *testing.T) {
+	runner := &fakePagedRunner{total: 7, pageSize: 3}
+	store := &FileCheckpointStore{Dir: t.TempDir()}
+	o := CheckpointOptions{Id: "export", Store: store}
+	p := &SearchClaimsParams{AssetId: "A123"}
+
+	var ids []string
+	for c, err := range ResumableIter(runner, p, SearchClaimsPages, o) {
+		require.NoError(t, err)
+		ids = append(ids, c.Id)
+		if len(ids) == 4 {
+			break
+		}
+	}
+	cp, err := store.Load("export")
+	require.NoError(t, err)
+	require.Equal(t, "3", cp.PageToken)
+	require.Equal(t, 1, cp.PageOffset)
+
+	// The restarted job resumes inside the second page without repeating item 3.
+	for c, err := range ResumableIter(runner, p, SearchClaimsPages, o) {
+		require.NoError(t, err)
+		ids = append(ids, c.Id)
+	}
+	require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, ids)
+	cp, err = store.Load("export")
+	require.NoError(t, err)
+	require.True(t, cp.Done)
+	require.Equal(t, 7, cp.Emitted)
+
+	for range ResumableIter(runner, p, SearchClaimsPages, o) {
+		require.Fail(t, "a done job should emit nothing")
+	}
+
+	for _, err := range ResumableIter(runner, &SearchClaimsParams{AssetId: "B456"}, SearchClaimsPages, o) {
+		require.ErrorIs(t, err, ErrCheckpointMismatch)
+	}
+}
+
+func TestResumableIterEveryItem(t *testing.T) {
+	runner := &fakePagedRunner{total: 7, pageSize: 3}
+	store := &FileCheckpointStore{Dir: t.TempDir()}
+	o := CheckpointOptions{Id: "export", Store: store, EveryItem: true}
+	p := &SearchClaimsParams{AssetId: "A123"}
+
+	// Simulate a crash while item 4 is processed by restoring the checkpoint saved at that point.
+	var crashed *Checkpoint
+	for c, err := range ResumableIter(runner, p, SearchClaimsPages, o) {
+		require.NoError(t, err)
+		if c.Id == "4" {
+			crashed, err = store.Load("export")
+			require.NoError(t, err)
+			break
+		}
+	}
+	require.NoError(t, store.Save("export", crashed))
+
+	var ids []string
+	for c, err := range ResumableIter(runner, p, SearchClaimsPages, o) {
+		require.NoError(t, err)
+		ids = append(ids, c.Id)
+	}
+	require.Equal(t, []string{"4", "5", "6"}, ids, "only the item in progress is emitted again")
+}
+
+func TestFileCheckpointStoreRejectsPathIds(t *testing.T) {
+	store := &FileCheckpointStore{Dir: filepath.Join(t.TempDir(), "checkpoints")}
+	for _, id := range []string{"", ".", "..", "../../x", "a/b", `a\b`, "x/.."} {
+		require.ErrorIs(t, store.Save(id, &Checkpoint{}), ErrInvalidCheckpointId, id)
+		_, err := store.Load(id)
+		require.ErrorIs(t, err, ErrInvalidCheckpointId, id)
+		require.ErrorIs(t, store.Delete(id), ErrInvalidCheckpointId, id)
+	}
+
+	require.NoError(t, store.Save("export-2024.v1", &Checkpoint{PageToken: "next"}))
+	c, err := store.Load("export-2024.v1")
+	require.NoError(t, err)
+	require.Equal(t, "next", c.PageToken)
+}
@@ -0,0 +1,351 @@
+package youtube
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBulkStatusConcurrency is the number of claims processed at once by BulkStatusChange.
+	DefaultBulkStatusConcurrency = 4
+)
+
+var (
+	ErrMissingClaims       = errors.New("missing claim ids or query")
+	ErrInvalidTargetStatus = errors.New("invalid target status")
+)
+
+// ClaimStatusOutcome is the outcome of a status change for a single claim.
+type ClaimStatusOutcome string
+
+const (
+	ClaimStatusOutcomeChanged        ClaimStatusOutcome = "changed"
+	ClaimStatusOutcomeAlreadyInState ClaimStatusOutcome = "alreadyInState"
+	ClaimStatusOutcomeFailed         ClaimStatusOutcome = "failed"
+	ClaimStatusOutcomeSkipped        ClaimStatusOutcome = "skipped"
+)
+
+// ClaimStatusResult is the result of a status change for a single claim.
+type ClaimStatusResult struct {
+	ClaimId string             `json:"claimId"`
+	Outcome ClaimStatusOutcome `json:"outcome"`
+
+	// Previous is the status of the claim before the change, if known.
+	Previous ClaimStatus `json:"previous,omitempty"`
+
+	// Reason describes why the change failed or was skipped.
+	Reason string `json:"reason,omitempty"`
+
+	// Err is the error which caused the change to fail.
+	Err error `json:"-"`
+
+	// JournalErr is the error which prevented the result from being recorded in the journal. It does not affect the
+	// outcome, but a restarted operation will process the claim again.
+	JournalErr error `json:"-"`
+
+	Time time.Time `json:"time"`
+}
+
+// BulkStatusProgress reports the progress of a BulkStatusChange after each claim.
+type BulkStatusProgress struct {
+	Done   int
+	Total  int
+	Result *ClaimStatusResult
+}
+
+// ClaimJournal records the claims a bulk operation has completed, so that a restarted operation does not process them
+// again.
+type ClaimJournal interface {
+	// Completed reports whether the claim was already completed.
+	Completed(claimId string) (bool, error)
+	// Record records the result for the claim.
+	Record(r *ClaimStatusResult) error
+}
+
+// MemoryClaimJournal is an in-memory ClaimJournal. The zero value is ready to use.
+type MemoryClaimJournal struct {
+	mu        sync.Mutex
+	completed map[string]bool
+}
+
+func (j *MemoryClaimJournal) Completed(claimId string) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.completed[claimId], nil
+}
+
+func (j *MemoryClaimJournal) Record(r *ClaimStatusResult) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.completed == nil {
+		j.completed = make(map[string]bool)
+	}
+	if r.Outcome == ClaimStatusOutcomeChanged || r.Outcome == ClaimStatusOutcomeAlreadyInState {
+		j.completed[r.ClaimId] = true
+	}
+	return nil
+}
+
+// FileClaimJournal is a ClaimJournal appending each result as a JSON line to the file at Path. Claims which were
+// changed or already in the target state are considered completed; failed claims are retried.
+type FileClaimJournal struct {
+	Path string
+
+	mu        sync.Mutex
+	completed map[string]bool
+}
+
+func (j *FileClaimJournal) load() error {
+	if j.completed != nil {
+		return nil
+	}
+	j.completed = make(map[string]bool)
+	f, err := os.Open(j.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r ClaimStatusResult
+		// A crash may leave a partially written last line, which is ignored.
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		if r.Outcome == ClaimStatusOutcomeChanged || r.Outcome == ClaimStatusOutcomeAlreadyInState {
+			j.completed[r.ClaimId] = true
+		}
+	}
+	return scanner.Err()
+}
+
+func (j *FileClaimJournal) Completed(claimId string) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.load(); err != nil {
+		return false, err
+	}
+	return j.completed[claimId], nil
+}
+
+func (j *FileClaimJournal) Record(r *ClaimStatusResult) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.load(); err != nil {
+		return err
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if r.Outcome == ClaimStatusOutcomeChanged || r.Outcome == ClaimStatusOutcomeAlreadyInState {
+		j.completed[r.ClaimId] = true
+	}
+	return nil
+}
+
+// BulkStatusChange changes the status of many claims, e.g. to release or reinstate them. Claims are given either as
+// ids or as a query; claims found by a query use the status from the search results, while claims given by id are
+// read with GetClaim first to detect claims already in the target state.
+type BulkStatusChange struct {
+	// ClaimIds are the claims to change.
+	ClaimIds []string
+
+	// Query selects the claims to change, in addition to ClaimIds.
+	Query *SearchClaimsParams
+
+	// Status is the target status.
+	Status ClaimStatus
+
+	// OnBehalfOfContentOwner identifies the content owner that the user is acting on behalf of.
+	OnBehalfOfContentOwner string
+
+	// Concurrency is the number of claims processed at once. Defaults to DefaultBulkStatusConcurrency.
+	Concurrency int
+
+	// Journal, if provided, records results so that a restarted operation skips completed claims.
+	Journal ClaimJournal
+
+	// Verify re-reads each changed claim with GetClaim to confirm its new status.
+	Verify bool
+
+	// OnProgress, if provided, is called after each claim. Calls are serialized.
+	OnProgress func(p BulkStatusProgress)
+}
+
+type bulkStatusTarget struct {
+	id     string
+	status ClaimStatus
+}
+
+// Run changes the status of the claims and returns a result per claim, in the order the claims were given. Failures
+// of individual claims, including failures to journal them, are reported in their results; an error is only returned
+// if the claims could not be listed or the context was cancelled, in which case the results of claims which were not
+// processed are nil.
+func (b *BulkStatusChange) Run(ctx context.Context, runner RequestRunner) ([]*ClaimStatusResult, error) {
+	if !b.Status.Valid() {
+		return nil, ErrInvalidTargetStatus
+	}
+	targets, err := b.targets(runner)
+	if err != nil {
+		return nil, err
+	}
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkStatusConcurrency
+	}
+
+	g, _ := newFanOut(ctx, concurrency)
+	var (
+		mu      sync.Mutex
+		done    int
+		results = make([]*ClaimStatusResult, len(targets))
+	)
+	for i, t := range targets {
+		g.Go(func() error {
+			r := b.change(runner, t)
+			if b.Journal != nil && r.Outcome != ClaimStatusOutcomeSkipped {
+				r.JournalErr = b.Journal.Record(r)
+			}
+			results[i] = r
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if b.OnProgress != nil {
+				b.OnProgress(BulkStatusProgress{
+					Done:   done,
+					Total:  len(targets),
+					Result: r,
+				})
+			}
+			return nil
+		})
+	}
+	// Failures are reported per claim, so the only error is the cancellation of ctx.
+	if err := g.Wait(); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// targets returns the deduplicated claims to change.
+func (b *BulkStatusChange) targets(runner RequestRunner) ([]bulkStatusTarget, error) {
+	if len(b.ClaimIds) == 0 && b.Query == nil {
+		return nil, ErrMissingClaims
+	}
+	seen := make(map[string]bool)
+	var targets []bulkStatusTarget
+	for _, id := range b.ClaimIds {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		targets = append(targets, bulkStatusTarget{id: id})
+	}
+	if b.Query != nil {
+		for c, err := range SearchClaimsIter(runner, b.Query, PageOptions{}) {
+			if err != nil {
+				return nil, err
+			}
+			if seen[c.Id] {
+				continue
+			}
+			seen[c.Id] = true
+			targets = append(targets, bulkStatusTarget{id: c.Id, status: ClaimStatus(c.Status)})
+		}
+	}
+	return targets, nil
+}
+
+// change changes the status of a single claim.
+func (b *BulkStatusChange) change(runner RequestRunner, t bulkStatusTarget) *ClaimStatusResult {
+	r := &ClaimStatusResult{
+		ClaimId:  t.id,
+		Previous: t.status,
+		Time:     time.Now(),
+	}
+	fail := func(reason string, err error) *ClaimStatusResult {
+		r.Outcome = ClaimStatusOutcomeFailed
+		r.Err = err
+		r.Reason = reason
+		if err != nil {
+			r.Reason = reason + ": " + err.Error()
+		}
+		return r
+	}
+
+	if b.Journal != nil {
+		completed, err := b.Journal.Completed(t.id)
+		if err != nil {
+			return fail("could not read journal", err)
+		}
+		if completed {
+			r.Outcome = ClaimStatusOutcomeSkipped
+			r.Reason = "completed in a previous run"
+			return r
+		}
+	}
+
+	if r.Previous == "" {
+		c, err := GetClaim(runner, &GetClaimParams{
+			ClaimId:                t.id,
+			OnBehalfOfContentOwner: b.OnBehalfOfContentOwner,
+		})
+		if err != nil {
+			return fail("could not get claim", err)
+		}
+		r.Previous = c.Status
+	}
+	if r.Previous == b.Status {
+		r.Outcome = ClaimStatusOutcomeAlreadyInState
+		return r
+	}
+
+	patched, err := PatchClaim(runner, &PatchClaimsParams{
+		ClaimId:                t.id,
+		OnBehalfOfContentOwner: b.OnBehalfOfContentOwner,
+		Status:                 b.Status,
+	})
+	if err != nil {
+		return fail("could not patch claim", err)
+	}
+	status := patched.Status
+	if b.Verify {
+		c, err := GetClaim(runner, &GetClaimParams{
+			ClaimId:                t.id,
+			OnBehalfOfContentOwner: b.OnBehalfOfContentOwner,
+		})
+		if err != nil {
+			return fail("could not verify claim", err)
+		}
+		status = c.Status
+	}
+	if status != "" && status != b.Status {
+		return fail(fmt.Sprintf("claim status is %s after the change", status), nil)
+	}
+	r.Outcome = ClaimStatusOutcomeChanged
+	return r
+}
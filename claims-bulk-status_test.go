@@ -0,0 +1,112 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClaimsRunner serves claims.get and claims.patch from a map of claim statuses. Patches of stuck claims are
+// acknowledged but not applied.
+type fakeClaimsRunner struct {
+	mu       sync.Mutex
+	statuses map[string]ClaimStatus
+	stuck    map[string]bool
+}
+
+func (r *fakeClaimsRunner) Run(req *Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := strings.TrimPrefix(req.Url, ClaimsUrl+"/")
+	status, ok := r.statuses[id]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}
+	if req.Method == http.MethodPatch {
+		var patch Claim
+		if err := json.NewDecoder(req.Body).Decode(&patch); err != nil {
+			return nil, err
+		}
+		if !r.stuck[id] {
+			r.statuses[id] = patch.Status
+		}
+		status = patch.Status
+	}
+	b, err := json.Marshal(Claim{Id: id, Status: status})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(b))}, nil
+}
+
+type failingJournal struct {
+	MemoryClaimJournal
+}
+
+func (j *failingJournal) Record(r *ClaimStatusResult) error {
+	return errors.New("disk full")
+}
+
+func TestBulkStatusChange(t *testing.T) {
+	runner := &fakeClaimsRunner{
+		statuses: map[string]ClaimStatus{
+			"a": ClaimStatusActive,
+			"b": ClaimStatusInactive,
+			"c": ClaimStatusActive,
+			"d": ClaimStatusActive,
+		},
+		stuck: map[string]bool{"d": true},
+	}
+	journal := &MemoryClaimJournal{}
+	require.NoError(t, journal.Record(&ClaimStatusResult{ClaimId: "c", Outcome: ClaimStatusOutcomeChanged}))
+
+	b := &BulkStatusChange{
+		ClaimIds: []string{"a", "b", "c", "d"},
+		Status:   ClaimStatusInactive,
+		Journal:  journal,
+		Verify:   true,
+	}
+	results, err := b.Run(context.Background(), runner)
+	require.NoError(t, err)
+
+	outcomes := make([]ClaimStatusOutcome, len(results))
+	for i, r := range results {
+		outcomes[i] = r.Outcome
+	}
+	require.Equal(t, []ClaimStatusOutcome{
+		ClaimStatusOutcomeChanged,
+		ClaimStatusOutcomeAlreadyInState,
+		ClaimStatusOutcomeSkipped,
+		ClaimStatusOutcomeFailed,
+	}, outcomes)
+	require.Equal(t, ClaimStatusActive, results[0].Previous)
+	require.Contains(t, results[3].Reason, "active after the change")
+	require.Equal(t, ClaimStatusActive, runner.statuses["c"], "skipped claims are not patched")
+
+	completed, err := journal.Completed("a")
+	require.NoError(t, err)
+	require.True(t, completed)
+}
+
+func TestBulkStatusChangeJournalError(t *testing.T) {
+	runner := &fakeClaimsRunner{statuses: map[string]ClaimStatus{"a": ClaimStatusActive}}
+	b := &BulkStatusChange{
+		ClaimIds: []string{"a"},
+		Status:   ClaimStatusInactive,
+		Journal:  &failingJournal{},
+	}
+	results, err := b.Run(context.Background(), runner)
+	require.NoError(t, err)
+	require.Equal(t, ClaimStatusOutcomeChanged, results[0].Outcome, "the claim was changed even if it was not journaled")
+	require.EqualError(t, results[0].JournalErr, "disk full")
+	require.Equal(t, ClaimStatusInactive, runner.statuses["a"])
+}
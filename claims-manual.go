@@ -0,0 +1,191 @@
+package youtube
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidManualClaimParams = errors.New("invalid manual claim params")
+	ErrInvalidTimeRange         = errors.New("invalid time range")
+	ErrOverlappingTimeRanges    = errors.New("overlapping time ranges")
+	ErrTimeRangeOutOfBounds     = errors.New("time range exceeds video duration")
+)
+
+var timestampRegexp = regexp.MustCompile(`^(?:(?:(\d+):)?(\d+):)?(\d+)(?:\.(\d{1,9}))?$`)
+
+// ParseTimestamp parses a timestamp such as "83", "1:23", "01:23.5" or "00:01:23.500" into an offset from the start
+// of a video.
+func ParseTimestamp(s string) (time.Duration, error) {
+	m := timestampRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+	hours, _ := strconv.ParseInt("0"+m[1], 10, 64)
+	minutes, _ := strconv.ParseInt("0"+m[2], 10, 64)
+	seconds, _ := strconv.ParseInt(m[3], 10, 64)
+	// Seconds and minutes are written with two digits below 60 unless they are the largest unit given.
+	if (m[2] != "" && (len(m[3]) != 2 || seconds >= 60)) || (m[1] != "" && (len(m[2]) != 2 || minutes >= 60)) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+	nanos, _ := strconv.ParseInt(m[4]+strings.Repeat("0", 9-len(m[4])), 10, 64)
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second +
+		time.Duration(nanos), nil
+}
+
+// ParseTimeRange parses a range such as "1:23-2:45" or "00:01:23.500-00:02:45".
+func ParseTimeRange(s string) (MatchInterval, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return MatchInterval{}, fmt.Errorf("%w: %q", ErrInvalidTimeRange, s)
+	}
+	start, err := ParseTimestamp(from)
+	if err != nil {
+		return MatchInterval{}, err
+	}
+	end, err := ParseTimestamp(to)
+	if err != nil {
+		return MatchInterval{}, err
+	}
+	if end <= start {
+		return MatchInterval{}, fmt.Errorf("%w: %q ends before it starts", ErrInvalidTimeRange, s)
+	}
+	return MatchInterval{Start: start, End: end}, nil
+}
+
+// ParseTimeRanges parses the ranges and checks that they don't overlap and that they end within the given length.
+// A length of zero skips the bounds check. The ranges are returned sorted by start.
+func ParseTimeRanges(ranges []string, length time.Duration) ([]MatchInterval, error) {
+	out := make([]MatchInterval, 0, len(ranges))
+	for _, r := range ranges {
+		i, err := ParseTimeRange(r)
+		if err != nil {
+			return nil, err
+		}
+		if length > 0 && i.End > length {
+			return nil, fmt.Errorf("%w: %s is past %s", ErrTimeRangeOutOfBounds, i, FormatDuration(length))
+		}
+		out = append(out, i)
+	}
+	sort.Slice(out, func(a, b int) bool {
+		return out[a].Start < out[b].Start
+	})
+	for n := 1; n < len(out); n++ {
+		if out[n].Start < out[n-1].End {
+			return nil, fmt.Errorf("%w: %s and %s", ErrOverlappingTimeRanges, out[n-1], out[n])
+		}
+	}
+	return out, nil
+}
+
+// InsertManualClaimParams are parameters for InsertManualClaim.
+type InsertManualClaimParams struct {
+	// OnBehalfOfContentOwner identifies the content owner that the user is acting on behalf of.
+	OnBehalfOfContentOwner string
+
+	AssetId string
+	VideoId string
+
+	// ContentType is one of the ClaimContentType constants.
+	ContentType string
+
+	Policy *Policy
+
+	// Ranges are the claimed parts of the video, such as "1:23-2:45" or "00:01:23.500-00:02:45".
+	Ranges []string
+}
+
+func (p *InsertManualClaimParams) Check() error {
+	switch {
+	case p.AssetId == "":
+		return fmt.Errorf("%w: missing asset id", ErrInvalidManualClaimParams)
+	case p.VideoId == "":
+		return fmt.Errorf("%w: missing video id", ErrInvalidManualClaimParams)
+	case !validClaimContentType(p.ContentType):
+		return fmt.Errorf("%w: invalid content type %q", ErrInvalidManualClaimParams, p.ContentType)
+	case p.Policy == nil:
+		return fmt.Errorf("%w: missing policy", ErrInvalidManualClaimParams)
+	case len(p.Ranges) == 0:
+		return fmt.Errorf("%w: missing ranges", ErrInvalidManualClaimParams)
+	}
+	return nil
+}
+
+// ManualClaimResult is the claim created by InsertManualClaim.
+type ManualClaimResult struct {
+	Claim *Claim
+
+	// Segments are the claimed ranges, sorted and normalized.
+	Segments []MatchInterval
+
+	// VideoDuration is the length of the claimed video.
+	VideoDuration time.Duration
+}
+
+// InsertManualClaim creates a manual claim on parts of a video given as human readable time ranges. The video's
+// duration is fetched through ListVideos and the ranges must fit within it without overlapping.
+//
+// @see https://developers.google.com/youtube/partner/reference/rest/v1/claims/insert
+func InsertManualClaim(runner RequestRunner, p *InsertManualClaimParams) (*ManualClaimResult, error) {
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	// Parse once before fetching the video so malformed ranges fail without a request.
+	if _, err := ParseTimeRanges(p.Ranges, 0); err != nil {
+		return nil, err
+	}
+
+	videos, err := ListVideos(runner, &ListVideoParams{
+		Parts: []ListVideoParamsPart{ListVideoParamsPartContentDetails},
+		Ids:   []string{p.VideoId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(videos.Items) == 0 || videos.Items[0].ContentDetails == nil {
+		return nil, ErrNotFound
+	}
+	length, err := videos.Items[0].ContentDetails.ParsedDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := ParseTimeRanges(p.Ranges, length)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*MatchSegment, len(segments))
+	for n, s := range segments {
+		matches[n] = &MatchSegment{
+			ManualSegment: &Segment2{
+				Start:    s.Start.Seconds(),
+				Duration: s.Duration().Seconds(),
+			},
+		}
+	}
+
+	claim, err := InsertClaim(runner, &InsertClaimParams{
+		OnBehalfOfContentOwner: p.OnBehalfOfContentOwner,
+		IsManualClaim:          true,
+		Claim: &Claim{
+			AssetId:     p.AssetId,
+			VideoId:     p.VideoId,
+			ContentType: p.ContentType,
+			Policy:      p.Policy,
+			MatchInfo:   &MatchInfo{MatchSegments: matches},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ManualClaimResult{
+		Claim:         claim,
+		Segments:      segments,
+		VideoDuration: length,
+	}, nil
+}
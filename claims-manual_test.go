@@ -0,0 +1,32 @@
+package youtube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeRanges(t *testing.T) {
+	segments, err := ParseTimeRanges([]string{"00:01:23.500-00:02:45", "0:10-1:00"}, 3*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []MatchInterval{
+		{Start: 10 * time.Second, End: time.Minute},
+		{Start: 83*time.Second + 500*time.Millisecond, End: 165 * time.Second},
+	}, segments)
+
+	_, err = ParseTimeRanges([]string{"1:00-2:00", "1:59-2:30"}, 0)
+	require.ErrorIs(t, err, ErrOverlappingTimeRanges)
+
+	_, err = ParseTimeRanges([]string{"2:00-3:01"}, 3*time.Minute)
+	require.ErrorIs(t, err, ErrTimeRangeOutOfBounds)
+
+	for _, r := range []string{"1:23", "2:00-1:00", "1:60-2:00", "0x1-2", "1:2-1:30"} {
+		_, err = ParseTimeRanges([]string{r}, 0)
+		require.ErrorIs(t, err, ErrInvalidTimeRange, r)
+	}
+
+	d, err := (&VideoContentDetails{Duration: "PT1H2M3S"}).ParsedDuration()
+	require.NoError(t, err)
+	require.Equal(t, time.Hour+2*time.Minute+3*time.Second, d)
+}
@@ -0,0 +1,390 @@
+package youtube
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ClaimQueryError describes a problem with a claim query.
+type ClaimQueryError struct {
+	// Term is the offending term of the query, if any.
+	Term string
+	// Msg describes the problem.
+	Msg string
+}
+
+func (e *ClaimQueryError) Error() string {
+	if e.Term == "" {
+		return "claim query: " + e.Msg
+	}
+	return fmt.Sprintf("claim query: %s: %q", e.Msg, e.Term)
+}
+
+// Check validates the params like Validate, but returns an error describing the problem. The error wraps
+// ErrInvalidClaimSearchParams.
+func (p *SearchClaimsParams) Check() error {
+	var primary []string
+	if p.AssetId != "" {
+		primary = append(primary, "assetId")
+	}
+	if p.Q != "" {
+		primary = append(primary, "q")
+	}
+	if p.ReferenceId != "" {
+		primary = append(primary, "referenceId")
+	}
+	if len(p.VideoIds) > 0 {
+		primary = append(primary, "videoId")
+	}
+	if p.Status != "" {
+		primary = append(primary, "status")
+	}
+	switch {
+	case len(primary) == 0:
+		return fmt.Errorf("%w: one of assetId, q, referenceId, videoId or status is required", ErrInvalidClaimSearchParams)
+	case len(primary) > 1:
+		return fmt.Errorf("%w: %s are mutually exclusive", ErrInvalidClaimSearchParams, strings.Join(primary, " and "))
+	}
+	if p.Status != "" && !p.Status.Valid() {
+		return fmt.Errorf("%w: invalid status %q", ErrInvalidClaimSearchParams, p.Status)
+	}
+	if p.IncludeThirdPartyClaims && len(p.VideoIds) == 0 {
+		return fmt.Errorf("%w: includeThirdPartyClaims requires videoId", ErrInvalidClaimSearchParams)
+	}
+	dates := []struct{ name, value string }{
+		{"createdAfter", p.CreatedAfter},
+		{"createdBefore", p.CreatedBefore},
+		{"statusModifiedAfter", p.StatusModifiedAfter},
+		{"statusModifiedBefore", p.StatusModifiedBefore},
+	}
+	for _, d := range dates {
+		if d.value == "" {
+			continue
+		}
+		if _, err := time.Parse(DateFormat, d.value); err != nil {
+			return fmt.Errorf("%w: %s must be a YYYY-MM-DD date, got %q", ErrInvalidClaimSearchParams, d.name, d.value)
+		}
+	}
+	if p.Sort != "" && p.Sort != ClaimSortDate && p.Sort != ClaimSortViewCount {
+		return fmt.Errorf("%w: sort must be %s or %s, got %q", ErrInvalidClaimSearchParams, ClaimSortDate, ClaimSortViewCount, p.Sort)
+	}
+	return nil
+}
+
+// ClaimQuery is a parsed claim query. A query with alternatives joined by OR compiles into one SearchClaimsParams per
+// alternative, as claimSearch only allows a single primary filter per request.
+type ClaimQuery struct {
+	Params []*SearchClaimsParams
+}
+
+// ParseClaimQuery compiles a human readable query into validated SearchClaimsParams. A query is a list of
+// whitespace-separated terms, e.g.
+//
+//	status:disputed created>=2025-01-01 contentType:audio origin:descriptiveSearch sort:viewCount
+//
+// The supported terms are:
+//
+//   - Primary filters, of which exactly one is allowed unless they are joined with OR: status:<status>,
+//     asset:<id>, reference:<id>, video:<id>[,<id>...] and q:<text>. Words without a key are appended to every
+//     q:<text> filter, or form a q filter of their own when there is none.
+//   - Date filters on created and modified (status modification), with the operators :, >=, >, < and <=.
+//   - contentType:<type>, origin:<origin>, sort:<date|viewCount>, inactiveReasons:<reason>[,<reason>...],
+//     partnerUploaded:<bool>, thirdParty:<bool>, shorts:<bool> and onBehalfOf:<content owner>.
+//
+// Values containing spaces may be quoted, e.g. q:"live at wembley". Alternatives are written as
+// status:disputed OR status:appealed.
+func ParseClaimQuery(query string) (*ClaimQuery, error) {
+	terms, err := tokenizeClaimQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		base    SearchClaimsParams
+		words   []string
+		groups  [][]*SearchClaimsParams
+		heads   []string // the first term of each group, for errors
+		pending bool     // the previous term was OR
+		primary bool     // the previous term was a primary filter
+	)
+	for i, term := range terms {
+		if term == "OR" {
+			if i == len(terms)-1 || !primary {
+				return nil, &ClaimQueryError{Term: term, Msg: "OR must join two primary filters"}
+			}
+			pending, primary = true, false
+			continue
+		}
+		primary = false
+		key, op, value := splitClaimQueryTerm(term)
+		if key == "" {
+			if pending {
+				return nil, &ClaimQueryError{Term: term, Msg: "OR must join two primary filters"}
+			}
+			words = append(words, value)
+			continue
+		}
+		filter, err := parseClaimQueryPrimary(key, op, value, term)
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			if pending {
+				groups[len(groups)-1] = append(groups[len(groups)-1], filter)
+				pending = false
+			} else {
+				groups = append(groups, []*SearchClaimsParams{filter})
+				heads = append(heads, term)
+			}
+			primary = true
+			continue
+		}
+		if pending {
+			return nil, &ClaimQueryError{Term: term, Msg: "OR must join two primary filters"}
+		}
+		if err := applyClaimQueryFilter(&base, key, op, value, term); err != nil {
+			return nil, err
+		}
+	}
+	if len(words) > 0 {
+		text := strings.Join(words, " ")
+		var merged bool
+		for _, group := range groups {
+			for _, filter := range group {
+				if filter.Q != "" {
+					filter.Q += " " + text
+					merged = true
+				}
+			}
+		}
+		if !merged {
+			groups = append(groups, []*SearchClaimsParams{{Q: text}})
+			heads = append(heads, text)
+		}
+	}
+
+	switch {
+	case len(groups) == 0:
+		return nil, &ClaimQueryError{Msg: "one of status, asset, reference, video or q is required"}
+	case len(groups) > 1:
+		return nil, &ClaimQueryError{
+			Msg: fmt.Sprintf("%s are mutually exclusive primary filters; join alternatives with OR", strings.Join(heads, " and ")),
+		}
+	}
+
+	q := &ClaimQuery{}
+	for _, filter := range groups[0] {
+		p := base
+		p.AssetId = filter.AssetId
+		p.Q = filter.Q
+		p.ReferenceId = filter.ReferenceId
+		p.VideoIds = filter.VideoIds
+		p.Status = filter.Status
+		if err := p.Check(); err != nil {
+			return nil, err
+		}
+		q.Params = append(q.Params, &p)
+	}
+	return q, nil
+}
+
+// Iter runs the searches of the query, yielding each claim once even if it matches several alternatives. Limits in the
+// page options apply to each search.
+func (q *ClaimQuery) Iter(runner RequestRunner, o PageOptions) iter.Seq2[*ClaimSnippet, error] {
+	return func(yield func(*ClaimSnippet, error) bool) {
+		seen := make(map[string]bool)
+		for _, p := range q.Params {
+			for c, err := range SearchClaimsIter(runner, p, o) {
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if seen[c.Id] {
+					continue
+				}
+				seen[c.Id] = true
+				if !yield(c, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// tokenizeClaimQuery splits the query on whitespace, keeping quoted values together and removing the quotes.
+func tokenizeClaimQuery(query string) ([]string, error) {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+		started bool
+	)
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case unicode.IsSpace(r) && !quoted:
+			if started {
+				terms = append(terms, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	if quoted {
+		return nil, &ClaimQueryError{Term: query, Msg: "unterminated quote"}
+	}
+	if started {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
+
+// splitClaimQueryTerm splits a term into key, operator and value. Terms without an operator have an empty key.
+func splitClaimQueryTerm(term string) (string, string, string) {
+	i := strings.IndexAny(term, ":<>")
+	if i <= 0 {
+		return "", "", term
+	}
+	op := term[i : i+1]
+	if op != ":" && i+1 < len(term) && term[i+1] == '=' {
+		op += "="
+	}
+	return term[:i], op, term[i+len(op):]
+}
+
+// parseClaimQueryPrimary returns params holding the primary filter of the term, or nil if the key is not a primary
+// filter.
+func parseClaimQueryPrimary(key, op, value, term string) (*SearchClaimsParams, error) {
+	var p SearchClaimsParams
+	switch strings.ToLower(key) {
+	case "status":
+		p.Status = ClaimStatus(value)
+		if !p.Status.Valid() {
+			return nil, &ClaimQueryError{Term: term, Msg: "unknown claim status"}
+		}
+	case "asset", "assetid":
+		p.AssetId = value
+	case "reference", "referenceid":
+		p.ReferenceId = value
+	case "video", "videoid":
+		p.VideoIds = strings.Split(value, ",")
+	case "q":
+		p.Q = value
+	default:
+		return nil, nil
+	}
+	if op != ":" {
+		return nil, &ClaimQueryError{Term: term, Msg: "only : is supported for " + key}
+	}
+	if value == "" {
+		return nil, &ClaimQueryError{Term: term, Msg: "missing value"}
+	}
+	return &p, nil
+}
+
+// applyClaimQueryFilter applies a secondary filter term to the params.
+func applyClaimQueryFilter(p *SearchClaimsParams, key, op, value, term string) error {
+	if value == "" {
+		return &ClaimQueryError{Term: term, Msg: "missing value"}
+	}
+	lower := strings.ToLower(key)
+	switch lower {
+	case "created", "modified", "statusmodified":
+		after, before, err := claimQueryDateRange(op, value)
+		if err != nil {
+			return &ClaimQueryError{Term: term, Msg: err.Error()}
+		}
+		if lower == "created" {
+			p.CreatedAfter = firstNonEmpty(after, p.CreatedAfter)
+			p.CreatedBefore = firstNonEmpty(before, p.CreatedBefore)
+		} else {
+			p.StatusModifiedAfter = firstNonEmpty(after, p.StatusModifiedAfter)
+			p.StatusModifiedBefore = firstNonEmpty(before, p.StatusModifiedBefore)
+		}
+		return nil
+	}
+	if op != ":" {
+		return &ClaimQueryError{Term: term, Msg: "only : is supported for " + key}
+	}
+	switch lower {
+	case "contenttype":
+		if !validClaimContentType(value) {
+			return &ClaimQueryError{Term: term, Msg: "content type must be audio, video or audiovisual"}
+		}
+		p.ContentType = value
+	case "origin":
+		p.Origin = value
+	case "sort":
+		if value != ClaimSortDate && value != ClaimSortViewCount {
+			return &ClaimQueryError{Term: term, Msg: "sort must be date or viewCount"}
+		}
+		p.Sort = value
+	case "inactivereasons":
+		p.InactiveReasons = value
+	case "onbehalfof":
+		p.OnBehalfOfContentOwner = value
+	case "partneruploaded", "thirdparty", "shorts":
+		b, ok := parseClaimQueryBool(value)
+		if !ok {
+			return &ClaimQueryError{Term: term, Msg: "expected true or false"}
+		}
+		switch lower {
+		case "partneruploaded":
+			p.PartnerUploaded = b
+		case "thirdparty":
+			p.IncludeThirdPartyClaims = b
+		default:
+			p.IsVideoShortsEligible = b
+		}
+	default:
+		return &ClaimQueryError{Term: term, Msg: "unknown filter"}
+	}
+	return nil
+}
+
+// claimQueryDateRange converts a date comparison into the inclusive after and exclusive before dates used by the API.
+func claimQueryDateRange(op, value string) (string, string, error) {
+	d, err := time.Parse(DateFormat, value)
+	if err != nil {
+		return "", "", fmt.Errorf("expected a YYYY-MM-DD date")
+	}
+	next := d.AddDate(0, 0, 1).Format(DateFormat)
+	switch op {
+	case ":":
+		return value, next, nil
+	case ">=":
+		return value, "", nil
+	case ">":
+		return next, "", nil
+	case "<":
+		return "", value, nil
+	case "<=":
+		return "", next, nil
+	}
+	return "", "", fmt.Errorf("unsupported operator %s", op)
+}
+
+func parseClaimQueryBool(s string) (bool, bool) {
+	switch strings.ToLower(s) {
+	case "true", "yes":
+		return true, true
+	case "false", "no":
+		return false, true
+	}
+	return false, false
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
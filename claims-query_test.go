@@ -0,0 +1,67 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClaimQuery(t *testing.T) {
+	tests := []struct {
+		query  string
+		params []SearchClaimsParams
+		err    string
+	}{
+		{
+			query: "status:disputed created>=2025-01-01 contentType:audio origin:descriptiveSearch sort:viewCount",
+			params: []SearchClaimsParams{{
+				Status:       ClaimStatusDisputed,
+				CreatedAfter: "2025-01-01",
+				ContentType:  ClaimContentTypeAudio,
+				Origin:       "descriptiveSearch",
+				Sort:         ClaimSortViewCount,
+			}},
+		},
+		{
+			query: "status:disputed OR status:appealed contentType:audio",
+			params: []SearchClaimsParams{
+				{Status: ClaimStatusDisputed, ContentType: ClaimContentTypeAudio},
+				{Status: ClaimStatusAppealed, ContentType: ClaimContentTypeAudio},
+			},
+		},
+		{query: "created:2025-01-31 asset:A1", params: []SearchClaimsParams{{AssetId: "A1", CreatedAfter: "2025-01-31", CreatedBefore: "2025-02-01"}}},
+		{query: "created>2025-01-31 asset:A1", params: []SearchClaimsParams{{AssetId: "A1", CreatedAfter: "2025-02-01"}}},
+		{query: "created<2025-01-31 asset:A1", params: []SearchClaimsParams{{AssetId: "A1", CreatedBefore: "2025-01-31"}}},
+		{query: "modified<=2025-01-31 asset:A1", params: []SearchClaimsParams{{AssetId: "A1", StatusModifiedBefore: "2025-02-01"}}},
+		{query: `q:"live at wembley" video:abc,def OR asset:A1`, err: `claim query: q:live at wembley and video:abc,def are mutually exclusive primary filters; join alternatives with OR`},
+		{query: `q:"live at wembley"`, params: []SearchClaimsParams{{Q: "live at wembley"}}},
+		{query: "q:foo bar", params: []SearchClaimsParams{{Q: "foo bar"}}},
+		{query: "foo bar", params: []SearchClaimsParams{{Q: "foo bar"}}},
+		{query: "status:active asset:A1", err: "claim query: status:active and asset:A1 are mutually exclusive primary filters; join alternatives with OR"},
+		{query: "status:active bar", err: "claim query: status:active and bar are mutually exclusive primary filters; join alternatives with OR"},
+		{query: "status:active OR", err: `claim query: OR must join two primary filters: "OR"`},
+		{query: "OR status:active", err: `claim query: OR must join two primary filters: "OR"`},
+		{query: "status:active OR contentType:audio", err: `claim query: OR must join two primary filters: "contentType:audio"`},
+		{query: "status:unknown", err: `claim query: unknown claim status: "status:unknown"`},
+		{query: "created>=2025-13-01 status:active", err: `claim query: expected a YYYY-MM-DD date: "created>=2025-13-01"`},
+		{query: `q:"open`, err: `claim query: unterminated quote: "q:\"open"`},
+		{query: "contentType:audio", err: "claim query: one of status, asset, reference, video or q is required"},
+		{query: "video:abc thirdParty:true", params: []SearchClaimsParams{{VideoIds: []string{"abc"}, IncludeThirdPartyClaims: true}}},
+		{query: "asset:A1 thirdParty:true", err: "invalid claim search params: includeThirdPartyClaims requires videoId"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseClaimQuery(tt.query)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			var params []SearchClaimsParams
+			for _, p := range q.Params {
+				params = append(params, *p)
+			}
+			require.Equal(t, tt.params, params)
+		})
+	}
+}
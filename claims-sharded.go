@@ -0,0 +1,160 @@
+package youtube
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DateFormat is the format of the date parameters of claimSearch.list.
+	DateFormat = "2006-01-02"
+
+	// DefaultShardMaxResults is the number of results above which a date window is split.
+	DefaultShardMaxResults = 2000
+
+	// DefaultShardConcurrency is the number of date windows fetched at once.
+	DefaultShardConcurrency = 4
+)
+
+var (
+	ErrInvalidShardWindow = errors.New("invalid shard window")
+)
+
+// ShardField selects which date range of SearchClaimsParams is sharded.
+type ShardField int
+
+const (
+	// ShardByCreated shards on CreatedAfter and CreatedBefore.
+	ShardByCreated ShardField = iota
+	// ShardByStatusModified shards on StatusModifiedAfter and StatusModifiedBefore.
+	ShardByStatusModified
+)
+
+// ShardedSearchOptions configure SearchClaimsSharded.
+type ShardedSearchOptions struct {
+	// Field is the date range to shard on.
+	Field ShardField
+
+	// From is the inclusive start of the date range. Only the date is used.
+	From time.Time
+
+	// To is the exclusive end of the date range. Only the date is used.
+	To time.Time
+
+	// MaxResults is the number of results above which a window is split in two. Defaults to DefaultShardMaxResults.
+	// Keep it below the result cap of claimSearch.
+	MaxResults int64
+
+	// Concurrency is the number of windows fetched at once. Defaults to DefaultShardConcurrency.
+	Concurrency int
+}
+
+type dateWindow struct {
+	from, to time.Time
+}
+
+func (w dateWindow) days() int {
+	return int(w.to.Sub(w.from).Hours() / 24)
+}
+
+func (w dateWindow) split() (dateWindow, dateWindow) {
+	mid := w.from.AddDate(0, 0, w.days()/2)
+	return dateWindow{w.from, mid}, dateWindow{mid, w.to}
+}
+
+func (w dateWindow) apply(p *SearchClaimsParams, field ShardField) {
+	switch field {
+	case ShardByStatusModified:
+		p.StatusModifiedAfter = w.from.Format(DateFormat)
+		p.StatusModifiedBefore = w.to.Format(DateFormat)
+	default:
+		p.CreatedAfter = w.from.Format(DateFormat)
+		p.CreatedBefore = w.to.Format(DateFormat)
+	}
+}
+
+// SearchClaimsSharded retrieves every claim matching the params within a date range, working around the result cap of
+// claimSearch. The range is recursively split whenever a window reports more than MaxResults results; windows are
+// fetched concurrently and claims are deduplicated by id. The date fields selected by Field are overwritten. A window
+// of a single day is never split further, so a day exceeding the cap may still be truncated by the API.
+//
+// Claims are returned ordered by creation time, then id.
+func SearchClaimsSharded(ctx context.Context, runner RequestRunner, p *SearchClaimsParams, o ShardedSearchOptions) ([]*ClaimSnippet, error) {
+	if !p.Validate() {
+		return nil, ErrInvalidClaimSearchParams
+	}
+	root := dateWindow{
+		from: truncateDay(o.From),
+		to:   truncateDay(o.To),
+	}
+	if root.days() < 1 {
+		return nil, ErrInvalidShardWindow
+	}
+	maxResults := o.MaxResults
+	if maxResults <= 0 {
+		maxResults = DefaultShardMaxResults
+	}
+	concurrency := o.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultShardConcurrency
+	}
+
+	g, ctx := newFanOut(ctx, concurrency)
+	var (
+		mu     sync.Mutex
+		claims = make(map[string]*ClaimSnippet)
+	)
+
+	var search func(w dateWindow) error
+	search = func(w dateWindow) error {
+		q := *p
+		q.PageToken = ""
+		w.apply(&q, o.Field)
+		for page, err := range SearchClaimsPages(runner, &q, PageOptions{}) {
+			if err != nil {
+				return err
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if page.PageToken == "" && page.PageInfo != nil && page.PageInfo.TotalResults > maxResults && w.days() > 1 {
+				left, right := w.split()
+				g.Go(func() error { return search(left) })
+				g.Go(func() error { return search(right) })
+				return nil
+			}
+			mu.Lock()
+			for _, c := range page.Items {
+				claims[c.Id] = c
+			}
+			mu.Unlock()
+		}
+		return nil
+	}
+
+	g.Go(func() error { return search(root) })
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	out := make([]*ClaimSnippet, 0, len(claims))
+	for _, c := range claims {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].TimeCreated != out[j].TimeCreated {
+			return out[i].TimeCreated < out[j].TimeCreated
+		}
+		return out[i].Id < out[j].Id
+	})
+	return out, nil
+}
+
+// truncateDay returns the start of the UTC day of t.
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,126 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeShardRunner answers claimSearch with the claims created within the requested window. The dup claim is returned
+// by every window, and requests for the fail window return an error.
+type fakeShardRunner struct {
+	claims []*ClaimSnippet
+	dup    *ClaimSnippet
+	fail   string
+
+	mu      sync.Mutex
+	windows []string
+	failed  bool
+	late    int
+}
+
+func (r *fakeShardRunner) Run(req *Request) (*http.Response, error) {
+	after, before := req.Params.Get("createdAfter"), req.Params.Get("createdBefore")
+	window := after + "/" + before
+	r.mu.Lock()
+	r.windows = append(r.windows, window)
+	if r.failed {
+		r.late++
+	}
+	if window == r.fail {
+		r.failed = true
+		r.mu.Unlock()
+		return nil, errors.New("window failed")
+	}
+	r.mu.Unlock()
+
+	out := ClaimSearchResponse{PageInfo: &PageInfo{}}
+	for _, c := range r.claims {
+		if day := c.TimeCreated[:len(DateFormat)]; day >= after && day < before {
+			out.Items = append(out.Items, c)
+		}
+	}
+	out.PageInfo.TotalResults = int64(len(out.Items))
+	if r.dup != nil {
+		out.Items = append(out.Items, r.dup)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+	}, nil
+}
+
+func TestSearchClaimsSharded(t *testing.T) {
+	runner := &fakeShardRunner{
+		claims: []*ClaimSnippet{
+			{Id: "a1", TimeCreated: "2024-01-01T10:00:00Z"},
+			{Id: "a2", TimeCreated: "2024-01-01T11:00:00Z"},
+			{Id: "a3", TimeCreated: "2024-01-01T12:00:00Z"},
+			{Id: "b1", TimeCreated: "2024-01-02T10:00:00Z"},
+			{Id: "c1", TimeCreated: "2024-01-03T10:00:00Z"},
+		},
+		dup: &ClaimSnippet{Id: "dup", TimeCreated: "2024-01-02T00:00:00Z"},
+	}
+	o := ShardedSearchOptions{
+		From:       time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
+		To:         time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		MaxResults: 2,
+	}
+	claims, err := SearchClaimsSharded(context.Background(), runner, &SearchClaimsParams{AssetId: "A1"}, o)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, c := range claims {
+		ids = append(ids, c.Id)
+	}
+	require.Equal(t, []string{"a1", "a2", "a3", "dup", "b1", "c1"}, ids, "claims are deduplicated and ordered")
+
+	// 5 results split the range, 4 split the first half again, and the first day is not split although it has 3.
+	require.ElementsMatch(t, []string{
+		"2024-01-01/2024-01-05",
+		"2024-01-01/2024-01-03",
+		"2024-01-03/2024-01-05",
+		"2024-01-01/2024-01-02",
+		"2024-01-02/2024-01-03",
+	}, runner.windows)
+}
+
+func TestSearchClaimsShardedError(t *testing.T) {
+	runner := &fakeShardRunner{
+		claims: []*ClaimSnippet{
+			{Id: "a1", TimeCreated: "2024-01-01T10:00:00Z"},
+			{Id: "a2", TimeCreated: "2024-01-01T11:00:00Z"},
+			{Id: "c1", TimeCreated: "2024-01-03T10:00:00Z"},
+			{Id: "d1", TimeCreated: "2024-01-04T10:00:00Z"},
+			{Id: "d2", TimeCreated: "2024-01-04T11:00:00Z"},
+		},
+		fail: "2024-01-01/2024-01-03",
+	}
+	o := ShardedSearchOptions{
+		From:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		MaxResults:  2,
+		Concurrency: 1,
+	}
+	_, err := SearchClaimsSharded(context.Background(), runner, &SearchClaimsParams{AssetId: "A1"}, o)
+	require.EqualError(t, err, "window failed")
+
+	// With a single slot, windows run one after the other, so no window is fetched once one has failed.
+	require.True(t, runner.failed)
+	require.Zero(t, runner.late)
+
+	_, err = SearchClaimsSharded(context.Background(), runner, &SearchClaimsParams{AssetId: "A1"}, ShardedSearchOptions{From: o.From, To: o.From})
+	require.ErrorIs(t, err, ErrInvalidShardWindow)
+}
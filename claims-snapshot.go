@@ -0,0 +1,247 @@
+package youtube
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	// ClaimSnapshotVersion is the version of the snapshot format written by WriteClaimSnapshot.
+	ClaimSnapshotVersion = 1
+
+	// ClaimSnapshotManifestSuffix is appended to the path of a snapshot to name its manifest.
+	ClaimSnapshotManifestSuffix = ".manifest.json"
+)
+
+var (
+	ErrInvalidSnapshot            = errors.New("invalid snapshot")
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+)
+
+// ClaimSnapshotKind is the type of the records of a snapshot.
+type ClaimSnapshotKind string
+
+const (
+	// ClaimSnapshotSnippets are ClaimSnippets, as returned by claimSearch.list. They carry no policy.
+	ClaimSnapshotSnippets ClaimSnapshotKind = "claimSnippet"
+	// ClaimSnapshotClaims are full Claims, as returned by claims.list.
+	ClaimSnapshotClaims ClaimSnapshotKind = "claim"
+)
+
+// ClaimSnapshotManifest describes a snapshot file. It is stored next to the snapshot, named after it with
+// ClaimSnapshotManifestSuffix.
+type ClaimSnapshotManifest struct {
+	Version                int               `json:"version"`
+	Kind                   ClaimSnapshotKind `json:"kind"`
+	OnBehalfOfContentOwner string            `json:"onBehalfOfContentOwner,omitempty"`
+	CreatedAt              time.Time         `json:"createdAt"`
+
+	// Count is the number of claims in the snapshot.
+	Count int `json:"count"`
+
+	// SHA256 is the hex encoded checksum of the snapshot file.
+	SHA256 string `json:"sha256"`
+}
+
+// WriteClaimSnapshot writes every claim of the iterator to a gzip compressed JSONL file at path, one claim per line,
+// followed by its manifest. Both files are written atomically; nothing is left behind if the iterator fails.
+func WriteClaimSnapshot[T ClaimSnippet | Claim](path, onBehalfOfContentOwner string, claims iter.Seq2[*T, error]) (*ClaimSnapshotManifest, error) {
+	m := &ClaimSnapshotManifest{
+		Version:                ClaimSnapshotVersion,
+		Kind:                   ClaimSnapshotClaims,
+		OnBehalfOfContentOwner: onBehalfOfContentOwner,
+		CreatedAt:              time.Now().UTC(),
+	}
+	if _, ok := any((*T)(nil)).(*ClaimSnippet); ok {
+		m.Kind = ClaimSnapshotSnippets
+	}
+
+	err := writeFileAtomic(path, func(w io.Writer) error {
+		hash := sha256.New()
+		gz := gzip.NewWriter(io.MultiWriter(w, hash))
+		enc := json.NewEncoder(gz)
+		for c, err := range claims {
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(c); err != nil {
+				return err
+			}
+			m.Count++
+		}
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		m.SHA256 = hex.EncodeToString(hash.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(path+ClaimSnapshotManifestSuffix, writeBytes(data)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExportClaimSnippets writes a snapshot of every claim found by claimSearch.list for the params.
+func ExportClaimSnippets(runner RequestRunner, p *SearchClaimsParams, path string) (*ClaimSnapshotManifest, error) {
+	return WriteClaimSnapshot(path, p.OnBehalfOfContentOwner, SearchClaimsIter(runner, p, PageOptions{}))
+}
+
+// ExportClaims writes a snapshot of every claim returned by claims.list for the params.
+func ExportClaims(runner RequestRunner, p *ListClaimsParams, path string) (*ClaimSnapshotManifest, error) {
+	return WriteClaimSnapshot(path, p.OnBehalfOfContentOwner, ListClaimsIter(runner, p, PageOptions{}))
+}
+
+// ClaimSnapshot is a snapshot loaded in memory. Snippets are loaded as Claims with the fields they share.
+type ClaimSnapshot struct {
+	Manifest *ClaimSnapshotManifest
+	Claims   map[string]*Claim
+}
+
+// LoadClaimSnapshot reads a snapshot and its manifest, checking the version, checksum and count.
+func LoadClaimSnapshot(path string) (*ClaimSnapshot, error) {
+	data, err := os.ReadFile(path + ClaimSnapshotManifestSuffix)
+	if err != nil {
+		return nil, err
+	}
+	var m ClaimSnapshotManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m.Version != ClaimSnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, m.Version)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	gz, err := gzip.NewReader(io.TeeReader(bufio.NewReader(f), hash))
+	if err != nil {
+		return nil, err
+	}
+	s := &ClaimSnapshot{
+		Manifest: &m,
+		Claims:   make(map[string]*Claim, m.Count),
+	}
+	dec := json.NewDecoder(gz)
+	var count int
+	for {
+		var c Claim
+		err := dec.Decode(&c)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.Claims[c.Id] = &c
+		count++
+	}
+	// Read the rest of the file, including the gzip trailer, into the checksum.
+	if _, err := io.Copy(io.Discard, gz); err != nil {
+		return nil, err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != m.SHA256 {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+	if count != m.Count {
+		return nil, fmt.Errorf("%w: expected %d claims, found %d", ErrInvalidSnapshot, m.Count, count)
+	}
+	return s, nil
+}
+
+// ClaimField is a field compared by DiffClaimSnapshots.
+type ClaimField string
+
+const (
+	ClaimFieldAsset  ClaimField = "asset"
+	ClaimFieldPolicy ClaimField = "policy"
+	ClaimFieldStatus ClaimField = "status"
+)
+
+// ChangedClaim is a claim present in both snapshots with different fields.
+type ChangedClaim struct {
+	Id     string
+	Before *Claim
+	After  *Claim
+	Fields []ClaimField
+}
+
+// ClaimSnapshotDiff is the difference between two snapshots. Claims are sorted by id.
+type ClaimSnapshotDiff struct {
+	Added   []*Claim
+	Removed []*Claim
+	Changed []*ChangedClaim
+
+	// FieldCounts is the number of changed claims per field.
+	FieldCounts map[ClaimField]int
+}
+
+// DiffClaimSnapshots compares two snapshots. Policies are only compared when both snapshots hold full claims.
+func DiffClaimSnapshots(before, after *ClaimSnapshot) *ClaimSnapshotDiff {
+	comparePolicy := before.Manifest.Kind == ClaimSnapshotClaims && after.Manifest.Kind == ClaimSnapshotClaims
+	d := &ClaimSnapshotDiff{
+		FieldCounts: make(map[ClaimField]int),
+	}
+	for id, a := range after.Claims {
+		b, ok := before.Claims[id]
+		if !ok {
+			d.Added = append(d.Added, a)
+			continue
+		}
+		var fields []ClaimField
+		if b.AssetId != a.AssetId {
+			fields = append(fields, ClaimFieldAsset)
+		}
+		if comparePolicy && !reflect.DeepEqual(b.Policy, a.Policy) {
+			fields = append(fields, ClaimFieldPolicy)
+		}
+		if b.Status != a.Status {
+			fields = append(fields, ClaimFieldStatus)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		for _, f := range fields {
+			d.FieldCounts[f]++
+		}
+		d.Changed = append(d.Changed, &ChangedClaim{Id: id, Before: b, After: a, Fields: fields})
+	}
+	for id, b := range before.Claims {
+		if _, ok := after.Claims[id]; !ok {
+			d.Removed = append(d.Removed, b)
+		}
+	}
+
+	byId := func(s []*Claim) func(i, j int) bool {
+		return func(i, j int) bool { return s[i].Id < s[j].Id }
+	}
+	sort.Slice(d.Added, byId(d.Added))
+	sort.Slice(d.Removed, byId(d.Removed))
+	sort.Slice(d.Changed, func(i, j int) bool {
+		return d.Changed[i].Id < d.Changed[j].Id
+	})
+	return d
+}
@@ -0,0 +1,54 @@
+package youtube
+
+import (
+	"iter"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func claimsSeq(claims ...*Claim) iter.Seq2[*Claim, error] {
+	return func(yield func(*Claim, error) bool) {
+		for _, c := range claims {
+			if !yield(c, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestClaimSnapshotDiff(t *testing.T) {
+	dir := t.TempDir()
+	before := filepath.Join(dir, "2026-09.jsonl.gz")
+	after := filepath.Join(dir, "2026-10.jsonl.gz")
+
+	m, err := WriteClaimSnapshot(before, "CO1", claimsSeq(
+		&Claim{Id: "a", AssetId: "A1", Status: ClaimStatusActive, Policy: &Policy{Id: "P1"}},
+		&Claim{Id: "b", AssetId: "A1", Status: ClaimStatusActive},
+	))
+	require.NoError(t, err)
+	require.Equal(t, ClaimSnapshotClaims, m.Kind)
+	require.Equal(t, 2, m.Count)
+
+	_, err = WriteClaimSnapshot(after, "CO1", claimsSeq(
+		&Claim{Id: "a", AssetId: "A2", Status: ClaimStatusInactive, Policy: &Policy{Id: "P2"}},
+		&Claim{Id: "c", AssetId: "A1", Status: ClaimStatusActive},
+	))
+	require.NoError(t, err)
+
+	b, err := LoadClaimSnapshot(before)
+	require.NoError(t, err)
+	a, err := LoadClaimSnapshot(after)
+	require.NoError(t, err)
+
+	d := DiffClaimSnapshots(b, a)
+	require.Len(t, d.Added, 1)
+	require.Equal(t, "c", d.Added[0].Id)
+	require.Len(t, d.Removed, 1)
+	require.Equal(t, "b", d.Removed[0].Id)
+	require.Len(t, d.Changed, 1)
+	require.Equal(t, []ClaimField{ClaimFieldAsset, ClaimFieldPolicy, ClaimFieldStatus}, d.Changed[0].Fields)
+	require.Equal(t, 1, d.FieldCounts[ClaimFieldPolicy])
+	require.Equal(t, "P2", d.Changed[0].After.Policy.Id)
+}
@@ -0,0 +1,238 @@
+package youtube
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ClaimEventType is the type of a claim history event.
+//
+// see https://developers.google.com/youtube/partner/reference/rest/v1/claimHistory#ClaimEvent
+type ClaimEventType string
+
+const (
+	ClaimEventTypeClaimCreate    ClaimEventType = "claimCreate"
+	ClaimEventTypeClaimRemove    ClaimEventType = "claimRemove"
+	ClaimEventTypeClaimUpdate    ClaimEventType = "claimUpdate"
+	ClaimEventTypeDisputeAppeal  ClaimEventType = "disputeAppeal"
+	ClaimEventTypeDisputeCreate  ClaimEventType = "disputeCreate"
+	ClaimEventTypeDisputeResolve ClaimEventType = "disputeResolve"
+	ClaimEventTypeStatusChange   ClaimEventType = "statusChange"
+	ClaimEventTypeOther          ClaimEventType = "other"
+)
+
+// Normalize returns the type in camelCase. The descriptions of typeDetails refer to event types in snake_case, such
+// as dispute_create, so both spellings are accepted.
+func (t ClaimEventType) Normalize() ClaimEventType {
+	return ClaimEventType(snakeToCamel(string(t)))
+}
+
+func (t ClaimEventType) Valid() bool {
+	switch t.Normalize() {
+	case ClaimEventTypeClaimCreate,
+		ClaimEventTypeClaimRemove,
+		ClaimEventTypeClaimUpdate,
+		ClaimEventTypeDisputeAppeal,
+		ClaimEventTypeDisputeCreate,
+		ClaimEventTypeDisputeResolve,
+		ClaimEventTypeStatusChange,
+		ClaimEventTypeOther:
+		return true
+	}
+	return false
+}
+
+// ClaimEventReason is the reason of a statusChange claim history event.
+//
+// see https://developers.google.com/youtube/partner/reference/rest/v1/claimHistory#ClaimEvent
+type ClaimEventReason string
+
+const (
+	ClaimEventReasonClosedAudioClaimOnVisualReference ClaimEventReason = "closedAudioClaimOnVisualReference"
+	ClaimEventReasonClosedDisabledMonetizationByOwner ClaimEventReason = "closedDisabledMonetizationByOwner"
+	ClaimEventReasonClosedManually                    ClaimEventReason = "closedManually"
+	ClaimEventReasonClosedNoAdsense                   ClaimEventReason = "closedNoAdsense"
+	ClaimEventReasonClosedOwnVideoMatch               ClaimEventReason = "closedOwnVideoMatch"
+	ClaimEventReasonClosedReferenceConflict           ClaimEventReason = "closedReferenceConflict"
+	ClaimEventReasonClosedReplacedByManualClaim       ClaimEventReason = "closedReplacedByManualClaim"
+	ClaimEventReasonClosedVideoRemoved                ClaimEventReason = "closedVideoRemoved"
+)
+
+// Normalize returns the reason in camelCase, accepting snake_case like ClaimEventType.Normalize.
+func (r ClaimEventReason) Normalize() ClaimEventReason {
+	return ClaimEventReason(snakeToCamel(string(r)))
+}
+
+// IsClosed reports whether the reason is one of the closed reasons, which make a claim inactive.
+func (r ClaimEventReason) IsClosed() bool {
+	return strings.HasPrefix(string(r.Normalize()), "closed")
+}
+
+// snakeToCamel converts snake_case to camelCase. Strings without underscores are returned unchanged.
+func snakeToCamel(s string) string {
+	if !strings.Contains(s, "_") {
+		return s
+	}
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// ParsedTime parses the time of the event.
+func (e *ClaimEvent) ParsedTime() (time.Time, error) {
+	return time.Parse(time.RFC3339, e.Time)
+}
+
+// NewStatus returns the claim status the event moves the claim to, or an empty status if the event does not change
+// the status or the new status is unknown. The status reported in TypeDetails.UpdateStatus takes precedence; a
+// statusChange event without it is resolved through its reason.
+func (e *ClaimEvent) NewStatus() ClaimStatus {
+	if e.TypeDetails != nil {
+		if s := ClaimStatus(e.TypeDetails.UpdateStatus); s.Valid() {
+			return s
+		}
+	}
+	switch e.Type.Normalize() {
+	case ClaimEventTypeClaimCreate:
+		return ClaimStatusActive
+	case ClaimEventTypeDisputeCreate:
+		return ClaimStatusDisputed
+	case ClaimEventTypeDisputeAppeal:
+		return ClaimStatusAppealed
+	case ClaimEventTypeClaimRemove:
+		return ClaimStatusInactive
+	case ClaimEventTypeStatusChange:
+		if e.Reason.IsClosed() {
+			return ClaimStatusInactive
+		}
+	}
+	return ""
+}
+
+// StatusInterval is a period during which a claim had a single status.
+type StatusInterval struct {
+	Status ClaimStatus
+
+	// From is when the claim entered the status.
+	From time.Time
+
+	// To is when the claim left the status. It is zero if the claim is still in the status.
+	To time.Time
+
+	// Duration is the time spent in the status. For the current status, it is measured up to the time the timeline
+	// was built for.
+	Duration time.Duration
+
+	// Event is the event which moved the claim into the status.
+	Event *ClaimEvent
+
+	// Source is the actor who moved the claim into the status, if known.
+	Source *Source
+}
+
+// ClaimTimeline is the status history of a claim as consecutive intervals.
+type ClaimTimeline struct {
+	ClaimId   string
+	Intervals []*StatusInterval
+}
+
+// BuildClaimTimeline turns the events of a claim history into status intervals. Events are ordered by time; events
+// which do not change the status, or whose time cannot be parsed, are ignored. The duration of the current status is
+// measured up to asOf.
+func BuildClaimTimeline(h *ClaimHistory, asOf time.Time) *ClaimTimeline {
+	type timedEvent struct {
+		event *ClaimEvent
+		time  time.Time
+	}
+	events := make([]timedEvent, 0, len(h.Event))
+	for _, e := range h.Event {
+		t, err := e.ParsedTime()
+		if err != nil {
+			continue
+		}
+		events = append(events, timedEvent{e, t})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].time.Before(events[j].time)
+	})
+
+	tl := &ClaimTimeline{ClaimId: h.Id}
+	var current *StatusInterval
+	for _, e := range events {
+		status := e.event.NewStatus()
+		if status == "" || (current != nil && current.Status == status) {
+			continue
+		}
+		if current != nil {
+			current.To = e.time
+			current.Duration = e.time.Sub(current.From)
+		}
+		current = &StatusInterval{
+			Status: status,
+			From:   e.time,
+			Event:  e.event,
+			Source: e.event.Source,
+		}
+		tl.Intervals = append(tl.Intervals, current)
+	}
+	if current != nil && asOf.After(current.From) {
+		current.Duration = asOf.Sub(current.From)
+	}
+	return tl
+}
+
+// Current returns the interval of the current status, or nil if the timeline is empty.
+func (tl *ClaimTimeline) Current() *StatusInterval {
+	if len(tl.Intervals) == 0 {
+		return nil
+	}
+	return tl.Intervals[len(tl.Intervals)-1]
+}
+
+// TimeIn returns the total time the claim spent in the status, e.g. how long it was disputed.
+func (tl *ClaimTimeline) TimeIn(status ClaimStatus) time.Duration {
+	var d time.Duration
+	for _, i := range tl.Intervals {
+		if i.Status == status {
+			d += i.Duration
+		}
+	}
+	return d
+}
+
+// Transitions returns the intervals entered by moving from one status to another, in order.
+func (tl *ClaimTimeline) Transitions(from, to ClaimStatus) []*StatusInterval {
+	var out []*StatusInterval
+	for n := 1; n < len(tl.Intervals); n++ {
+		if tl.Intervals[n-1].Status == from && tl.Intervals[n].Status == to {
+			out = append(out, tl.Intervals[n])
+		}
+	}
+	return out
+}
+
+// Entered returns the intervals in which the claim entered the status, in order. The Source of each interval tells
+// who moved the claim, e.g. who released it.
+func (tl *ClaimTimeline) Entered(status ClaimStatus) []*StatusInterval {
+	var out []*StatusInterval
+	for _, i := range tl.Intervals {
+		if i.Status == status {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Reinstatements returns how many times the claim was made active again after being disputed, appealed or inactive.
+func (tl *ClaimTimeline) Reinstatements() int {
+	var n int
+	for _, from := range []ClaimStatus{ClaimStatusDisputed, ClaimStatusAppealed, ClaimStatusInactive} {
+		n += len(tl.Transitions(from, ClaimStatusActive))
+	}
+	return n
+}
@@ -0,0 +1,88 @@
+package youtube
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// claimHistoryFixture is a claimHistory.get response. Events are deliberately out of order.
+const claimHistoryFixture = `{
+  "kind": "youtubePartner#claimHistory",
+  "id": "c1",
+  "uploaderChannelId": "UC123",
+  "event": [
+    {
+      "kind": "youtubePartner#claimEvent",
+      "time": "2024-01-02T00:00:00.000Z",
+      "type": "disputeCreate",
+      "source": {"type": "uploader"},
+      "typeDetails": {"disputeReason": "fairUse", "disputeNotes": "commentary"}
+    },
+    {
+      "kind": "youtubePartner#claimEvent",
+      "time": "2024-01-01T00:00:00.000Z",
+      "type": "claimCreate",
+      "source": {"type": "system"}
+    },
+    {
+      "kind": "youtubePartner#claimEvent",
+      "time": "2024-01-05T00:00:00.000Z",
+      "type": "statusChange",
+      "source": {"type": "partner", "contentOwnerId": "CO1", "userEmail": "reviewer@example.com"},
+      "typeDetails": {"updateStatus": "active"}
+    },
+    {
+      "kind": "youtubePartner#claimEvent",
+      "time": "2024-01-06T00:00:00.000Z",
+      "type": "disputeCreate",
+      "source": {"type": "uploader"},
+      "typeDetails": {"disputeReason": "licensed"}
+    },
+    {
+      "kind": "youtubePartner#claimEvent",
+      "time": "2024-01-07T00:00:00.000Z",
+      "type": "statusChange",
+      "reason": "closedManually",
+      "source": {"type": "partner", "contentOwnerId": "CO1", "userEmail": "ops@example.com"}
+    }
+  ]
+}`
+
+func TestBuildClaimTimeline(t *testing.T) {
+	var h ClaimHistory
+	require.NoError(t, json.Unmarshal([]byte(claimHistoryFixture), &h))
+
+	tl := BuildClaimTimeline(&h, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	var statuses []ClaimStatus
+	for _, i := range tl.Intervals {
+		statuses = append(statuses, i.Status)
+	}
+	require.Equal(t, []ClaimStatus{
+		ClaimStatusActive,
+		ClaimStatusDisputed,
+		ClaimStatusActive,
+		ClaimStatusDisputed,
+		ClaimStatusInactive,
+	}, statuses)
+
+	require.Equal(t, 4*24*time.Hour, tl.TimeIn(ClaimStatusDisputed))
+	require.Equal(t, 1, tl.Reinstatements())
+
+	released := tl.Entered(ClaimStatusInactive)
+	require.Len(t, released, 1)
+	require.Equal(t, "ops@example.com", released[0].Source.UserEmail)
+
+	current := tl.Current()
+	require.Equal(t, ClaimStatusInactive, current.Status)
+	require.Equal(t, 3*24*time.Hour, current.Duration)
+	require.True(t, current.To.IsZero())
+}
+
+func TestClaimEventNormalize(t *testing.T) {
+	require.Equal(t, ClaimEventTypeDisputeCreate, ClaimEventType("dispute_create").Normalize())
+	require.True(t, ClaimEventReason("closed_own_video_match").IsClosed())
+	require.Equal(t, ClaimStatusAppealed, (&ClaimEvent{Type: "dispute_appeal"}).NewStatus())
+}
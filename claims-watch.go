@@ -0,0 +1,413 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultClaimWatchInterval is the time between two polls of a ClaimWatcher.
+	DefaultClaimWatchInterval = 5 * time.Minute
+
+	// DefaultClaimWatchOverlap is how far before the high-water mark a ClaimWatcher searches again. statusModifiedAfter
+	// only takes a date, so the overlap must cover at least a day for changes late on the day of the mark to be seen.
+	DefaultClaimWatchOverlap = 24 * time.Hour
+)
+
+var (
+	ErrInvalidClaimWatchId = errors.New("invalid claim watch id")
+	ErrMissingClaimWatchId = errors.New("missing claim watch id")
+)
+
+// ClaimChangeType is the type of a change reported by a ClaimWatcher.
+type ClaimChangeType string
+
+const (
+	// ClaimChangeNew is reported for a claim the watcher has not seen before.
+	ClaimChangeNew ClaimChangeType = "new"
+	// ClaimChangeStatus is reported when the status of a known claim changed.
+	ClaimChangeStatus ClaimChangeType = "status"
+	// ClaimChangePolicy is reported when the policy of a known claim changed. Only reported with FetchPolicies.
+	ClaimChangePolicy ClaimChangeType = "policy"
+)
+
+// ClaimChange is a change to a claim observed by a ClaimWatcher.
+type ClaimChange struct {
+	Type    ClaimChangeType
+	ClaimId string
+
+	// Time is when the status of the claim was last modified.
+	Time time.Time
+
+	FromStatus ClaimStatus
+	ToStatus   ClaimStatus
+
+	// FromPolicy and ToPolicy are only set with FetchPolicies.
+	FromPolicy *Policy
+	ToPolicy   *Policy
+
+	// Snippet is the claim as returned by the search.
+	Snippet *ClaimSnippet
+
+	// Claim is the full claim, only set with FetchPolicies.
+	Claim *Claim
+}
+
+// WatchedClaim is the last known state of a claim.
+type WatchedClaim struct {
+	Status                 ClaimStatus `json:"status"`
+	Policy                 *Policy     `json:"policy,omitempty"`
+	TimeStatusLastModified time.Time   `json:"timeStatusLastModified,omitzero"`
+}
+
+// ClaimWatchState is the persisted state of a ClaimWatcher.
+type ClaimWatchState struct {
+	// Watermark is the latest status modification time seen.
+	Watermark time.Time `json:"watermark,omitzero"`
+
+	// Claims is the last known state of each claim, keyed by claim id.
+	Claims map[string]*WatchedClaim `json:"claims"`
+
+	// UpdatedAt is the time the state was last saved.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ClaimWatchStore persists the state of claim watchers keyed by watcher id. Load returns ErrNotFound if there is no
+// state for the id.
+type ClaimWatchStore interface {
+	Load(id string) (*ClaimWatchState, error)
+	Save(id string, s *ClaimWatchState) error
+}
+
+// MemoryClaimWatchStore keeps the state of claim watchers in memory.
+type MemoryClaimWatchStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+func (s *MemoryClaimWatchStore) Load(id string) (*ClaimWatchState, error) {
+	s.mu.Lock()
+	data, ok := s.states[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	var out ClaimWatchState
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *MemoryClaimWatchStore) Save(id string, state *ClaimWatchState) error {
+	// Stored encoded so that the caller's state is not shared.
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.states == nil {
+		s.states = make(map[string][]byte)
+	}
+	s.states[id] = data
+	return nil
+}
+
+// FileClaimWatchStore stores the state of each watcher as a JSON file named after the watcher id in Dir. Ids
+// containing path separators or ".." are rejected with ErrInvalidClaimWatchId.
+type FileClaimWatchStore struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+func (s *FileClaimWatchStore) path(id string) (string, error) {
+	if !isValidFileId(id) {
+		return "", ErrInvalidClaimWatchId
+	}
+	return filepath.Join(s.Dir, id+".watch.json"), nil
+}
+
+func (s *FileClaimWatchStore) Load(id string) (*ClaimWatchState, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out ClaimWatchState
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *FileClaimWatchStore) Save(id string, state *ClaimWatchState) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(path, writeBytes(data))
+}
+
+// ClaimWatcher polls claimSearch for claims whose status was modified since a persisted high-water mark and reports
+// what changed compared to the last known state of each claim.
+//
+// Every poll searches from the day of the mark minus Overlap, so claims seen by the previous poll are returned again;
+// they are only reported if they actually changed. Changes are delivered at least once: the state is saved after all
+// changes of a poll were delivered, so a crash in between repeats them.
+//
+// The search params must select claims by one primary filter. Note that with a Status filter, claims leaving that
+// status are no longer returned, so their change is not seen. The state keeps every claim seen, unless Retention is
+// set.
+type ClaimWatcher struct {
+	// Id identifies the watcher in the Store. This is required.
+	Id string
+
+	// Params are the search params. The status modification dates and page token are overwritten.
+	Params *SearchClaimsParams
+
+	// Store persists the state. Defaults to a MemoryClaimWatchStore, which does not survive restarts.
+	Store ClaimWatchStore
+
+	// Since is where a watcher without state starts. Defaults to the start of the current day.
+	Since time.Time
+
+	// Interval is the time between two polls of Run. Defaults to DefaultClaimWatchInterval.
+	Interval time.Duration
+
+	// Overlap is how far before the mark each poll searches. Defaults to DefaultClaimWatchOverlap.
+	Overlap time.Duration
+
+	// Retention forgets claims whose status was last modified this long before the mark. A forgotten claim which
+	// changes again is reported as new. Zero keeps every claim.
+	Retention time.Duration
+
+	// FetchPolicies retrieves the full claims of every search result to report policy changes. Policy changes which
+	// do not also modify the status are only seen when the claim shows up in the search for another reason.
+	FetchPolicies bool
+
+	// Concurrency is the number of requests made at once. Defaults to DefaultShardConcurrency.
+	Concurrency int
+
+	// OnChange, if set, is called with each change. Returning an error stops the poll without saving the state.
+	OnChange func(ClaimChange) error
+
+	// Changes, if set, receives each change.
+	Changes chan<- ClaimChange
+
+	// OnError, if set, is called with the error of a failed poll and Run keeps going. Otherwise Run returns it.
+	OnError func(error)
+
+	mu    sync.Mutex
+	state *ClaimWatchState
+}
+
+// Run polls immediately and then every Interval until the context is done or a poll fails without OnError.
+func (w *ClaimWatcher) Run(ctx context.Context, runner RequestRunner) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultClaimWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := w.Poll(ctx, runner); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.OnError == nil {
+				return err
+			}
+			w.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll searches once, delivers the changes found and saves the new state. The changes are also returned, ordered by
+// time.
+func (w *ClaimWatcher) Poll(ctx context.Context, runner RequestRunner) ([]ClaimChange, error) {
+	if w.Id == "" {
+		return nil, ErrMissingClaimWatchId
+	}
+	if w.Params == nil {
+		return nil, ErrInvalidClaimSearchParams
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.Store == nil {
+		w.Store = &MemoryClaimWatchStore{}
+	}
+	if w.state == nil {
+		state, err := w.Store.Load(w.Id)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			state = &ClaimWatchState{}
+		case err != nil:
+			return nil, err
+		}
+		if state.Claims == nil {
+			state.Claims = make(map[string]*WatchedClaim)
+		}
+		w.state = state
+	}
+
+	overlap := w.Overlap
+	if overlap <= 0 {
+		overlap = DefaultClaimWatchOverlap
+	}
+	from := w.Since
+	if !w.state.Watermark.IsZero() {
+		from = w.state.Watermark.Add(-overlap)
+	} else if from.IsZero() {
+		from = time.Now()
+	}
+
+	// statusModifiedBefore is exclusive of its date, so search up to the start of tomorrow.
+	snippets, err := SearchClaimsSharded(ctx, runner, w.Params, ShardedSearchOptions{
+		Field:       ShardByStatusModified,
+		From:        from,
+		To:          truncateDay(time.Now()).AddDate(0, 0, 1),
+		Concurrency: w.Concurrency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]*Claim)
+	if w.FetchPolicies && len(snippets) > 0 {
+		ids := make([]string, len(snippets))
+		for i, s := range snippets {
+			ids[i] = s.Id
+		}
+		res, err := BulkListClaims(ctx, runner, ids, &ListClaimsParams{
+			OnBehalfOfContentOwner: w.Params.OnBehalfOfContentOwner,
+		}, BulkOptions{Concurrency: w.Concurrency})
+		if err != nil {
+			return nil, err
+		}
+		claims = res.ById
+	}
+
+	changes, next := w.diff(snippets, claims)
+	for _, c := range changes {
+		if w.OnChange != nil {
+			if err := w.OnChange(c); err != nil {
+				return changes, err
+			}
+		}
+		if w.Changes != nil {
+			select {
+			case w.Changes <- c:
+			case <-ctx.Done():
+				return changes, ctx.Err()
+			}
+		}
+	}
+
+	w.state = next
+	w.state.UpdatedAt = time.Now()
+	return changes, w.Store.Save(w.Id, w.state)
+}
+
+// diff compares the search results with the current state and returns the changes and the next state.
+func (w *ClaimWatcher) diff(snippets []*ClaimSnippet, claims map[string]*Claim) ([]ClaimChange, *ClaimWatchState) {
+	next := &ClaimWatchState{
+		Watermark: w.state.Watermark,
+		Claims:    make(map[string]*WatchedClaim, len(w.state.Claims)),
+	}
+	for id, c := range w.state.Claims {
+		next.Claims[id] = c
+	}
+
+	var changes []ClaimChange
+	for _, s := range snippets {
+		modified, _ := time.Parse(time.RFC3339, s.TimeStatusLastModified)
+		cur := &WatchedClaim{
+			Status:                 ClaimStatus(s.Status),
+			TimeStatusLastModified: modified,
+		}
+		claim := claims[s.Id]
+		if claim != nil {
+			cur.Policy = claim.Policy
+		}
+		change := ClaimChange{
+			ClaimId:  s.Id,
+			Time:     modified,
+			ToStatus: cur.Status,
+			ToPolicy: cur.Policy,
+			Snippet:  s,
+			Claim:    claim,
+		}
+
+		prev, ok := w.state.Claims[s.Id]
+		switch {
+		case !ok:
+			change.Type = ClaimChangeNew
+			changes = append(changes, change)
+		default:
+			change.FromStatus = prev.Status
+			change.FromPolicy = prev.Policy
+			if prev.Status != cur.Status {
+				change.Type = ClaimChangeStatus
+				changes = append(changes, change)
+			}
+			if claim == nil {
+				// Without the full claim the policy is unknown; keep the last known one.
+				cur.Policy = prev.Policy
+			} else if prev.Policy != nil && !reflect.DeepEqual(prev.Policy, cur.Policy) {
+				change.Type = ClaimChangePolicy
+				changes = append(changes, change)
+			}
+		}
+
+		next.Claims[s.Id] = cur
+		if modified.After(next.Watermark) {
+			next.Watermark = modified
+		}
+	}
+
+	if w.Retention > 0 {
+		cutoff := next.Watermark.Add(-w.Retention)
+		for id, c := range next.Claims {
+			if c.TimeStatusLastModified.Before(cutoff) {
+				delete(next.Claims, id)
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].Time.Equal(changes[j].Time) {
+			return changes[i].Time.Before(changes[j].Time)
+		}
+		return changes[i].ClaimId < changes[j].ClaimId
+	})
+	return changes, next
+}
@@ -0,0 +1,77 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSearchRunner serves the same claimSearch results whatever the params.
+type fakeSearchRunner struct {
+	claims []*ClaimSnippet
+}
+
+func (r *fakeSearchRunner) Run(req *Request) (*http.Response, error) {
+	b, err := json.Marshal(ClaimSearchResponse{Items: r.claims})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+	}, nil
+}
+
+func TestClaimWatcherPoll(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	runner := &fakeSearchRunner{claims: []*ClaimSnippet{
+		{Id: "a", Status: "active", TimeStatusLastModified: now.Add(-time.Hour).Format(time.RFC3339)},
+		{Id: "b", Status: "disputed", TimeStatusLastModified: now.Add(-2 * time.Hour).Format(time.RFC3339)},
+	}}
+	store := &MemoryClaimWatchStore{}
+	w := &ClaimWatcher{Id: "w", Params: &SearchClaimsParams{AssetId: "A1"}, Store: store}
+
+	changes, err := w.Poll(context.Background(), runner)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, "b", changes[0].ClaimId)
+	require.Equal(t, ClaimChangeNew, changes[0].Type)
+
+	// Results of the overlap window are not reported again.
+	changes, err = w.Poll(context.Background(), runner)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	runner.claims[1] = &ClaimSnippet{Id: "b", Status: "inactive", TimeStatusLastModified: now.Format(time.RFC3339)}
+
+	// A new watcher resumes from the saved state.
+	w = &ClaimWatcher{Id: "w", Params: &SearchClaimsParams{AssetId: "A1"}, Store: store}
+	changes, err = w.Poll(context.Background(), runner)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, ClaimChangeStatus, changes[0].Type)
+	require.Equal(t, ClaimStatusDisputed, changes[0].FromStatus)
+	require.Equal(t, ClaimStatusInactive, changes[0].ToStatus)
+
+	state, err := store.Load("w")
+	require.NoError(t, err)
+	require.True(t, state.Watermark.Equal(now))
+}
+
+func TestFileClaimWatchStoreRejectsPathIds(t *testing.T) {
+	store := &FileClaimWatchStore{Dir: t.TempDir()}
+	for _, id := range []string{"", "..", "../x", "a/b"} {
+		require.ErrorIs(t, store.Save(id, &ClaimWatchState{}), ErrInvalidClaimWatchId, id)
+		_, err := store.Load(id)
+		require.ErrorIs(t, err, ErrInvalidClaimWatchId, id)
+	}
+	require.NoError(t, store.Save("disputes", &ClaimWatchState{}))
+	_, err := store.Load("disputes")
+	require.NoError(t, err)
+}
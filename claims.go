@@ -38,6 +38,10 @@ const (
 
 	ClaimSortDate      = "date"
 	ClaimSortViewCount = "viewCount"
+
+	ClaimContentTypeAudio       = "audio"
+	ClaimContentTypeVideo       = "video"
+	ClaimContentTypeAudiovisual = "audiovisual"
 )
 
 var (
@@ -59,6 +63,14 @@ func (s ClaimStatus) Valid() bool {
 	return false
 }
 
+func validClaimContentType(t string) bool {
+	switch t {
+	case ClaimContentTypeAudio, ClaimContentTypeVideo, ClaimContentTypeAudiovisual:
+		return true
+	}
+	return false
+}
+
 // Claim represents a YouTube Content ID claim resource.
 //
 // see https://developers.google.com/youtube/partner/reference/rest/v1/claims#Claim
@@ -237,16 +249,16 @@ type ClaimEvent struct {
 
 	// Reason: The reason the claim status changed. This field is only populated
 	// when the type is statusChange.
-	Reason string `json:"reason,omitempty"`
+	Reason ClaimEventReason `json:"reason,omitempty"`
 
 	// Source: The source information for the event.
 	Source *Source `json:"source,omitempty"`
 
-	// Time: The time when the event occurred.
+	// Time: The time when the event occurred. Use ParsedTime to read it.
 	Time string `json:"time,omitempty"`
 
 	// Type: The type of the claim event.
-	Type string `json:"type,omitempty"`
+	Type ClaimEventType `json:"type,omitempty"`
 
 	// TypeDetails: Additional details about the event type.
 	TypeDetails *TypeDetails `json:"typeDetails,omitempty"`
@@ -447,6 +459,11 @@ func (p *SearchClaimsParams) Values() url.Values {
 	return vals
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *SearchClaimsParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // SearchClaims retrieves a list of claims that match the search criteria. The
 // API response uses pagination. You must specify one and only one search filter:
 // assetId, videoId, q, referenceId, or status.
@@ -577,6 +594,11 @@ func (p *ListClaimsParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListClaimsParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListClaims retrieves a list of claims administered by the content owner
 // associated with the currently authenticated user. The API response uses
 // pagination.
@@ -658,7 +680,9 @@ func InsertClaim(runner RequestRunner, p *InsertClaimParams) (*Claim, error) {
 
 // PatchClaimsParams are parameters for the claims.patch method, which patches
 // an existing claim by updating the fields included in the request body. This
-// method supports patch semantics.
+// method supports patch semantics. Only the fields which are set are sent;
+// pointer fields track presence so that false and empty values can be sent
+// explicitly (see Ptr).
 type PatchClaimsParams struct {
 	// ClaimId: The claimId parameter specifies the claim ID of the claim being
 	// patched. This is a required parameter.
@@ -672,10 +696,38 @@ type PatchClaimsParams struct {
 	// Status: The status to set on the claim. The claim's status must be valid
 	// per the ClaimStatus enumeration.
 	Status ClaimStatus
+
+	// Policy: The policy to apply to the claim, either a saved policy
+	// referenced by Id or a policy with inline rules.
+	Policy *Policy
+
+	// BlockOutsideOwnership: Whether the claimed video should be blocked
+	// anywhere it is not explicitly owned.
+	BlockOutsideOwnership *bool
+
+	// ContentType: Whether the claim covers the audio, video, or audiovisual
+	// portion of the claimed content.
+	ContentType string
+
+	// MatchInfo: The match information of the claim. Only the manual segments
+	// of manual claims can be changed.
+	MatchInfo *MatchInfo
 }
 
 func (p *PatchClaimsParams) Validate() bool {
-	return p.ClaimId != "" && p.Status != "" && p.Status.Valid()
+	if p.ClaimId == "" {
+		return false
+	}
+	if p.Status == "" && p.Policy == nil && p.BlockOutsideOwnership == nil && p.ContentType == "" && p.MatchInfo == nil {
+		return false
+	}
+	if p.Status != "" && !p.Status.Valid() {
+		return false
+	}
+	if p.ContentType != "" && !validClaimContentType(p.ContentType) {
+		return false
+	}
+	return true
 }
 
 func (p *PatchClaimsParams) Values() url.Values {
@@ -691,6 +743,18 @@ func (p *PatchClaimsParams) Body() (io.Reader, error) {
 	if p.Status != "" && p.Status.Valid() {
 		m["status"] = p.Status
 	}
+	if p.Policy != nil {
+		m["policy"] = p.Policy
+	}
+	if p.BlockOutsideOwnership != nil {
+		m["blockOutsideOwnership"] = *p.BlockOutsideOwnership
+	}
+	if p.ContentType != "" {
+		m["contentType"] = p.ContentType
+	}
+	if p.MatchInfo != nil {
+		m["matchInfo"] = p.MatchInfo
+	}
 	if len(m) == 0 {
 		return nil, ErrInvalidPatchClaimsParams
 	}
@@ -702,7 +766,8 @@ func (p *PatchClaimsParams) Body() (io.Reader, error) {
 }
 
 // PatchClaim patches an existing claim by only updating the fields included in
-// the request body. Use this method to update a claim's status. This method
+// the request body. Use this method to update a claim's status, policy,
+// content type, blockOutsideOwnership or manual match segments. This method
 // supports patch semantics -- only the fields included in the request body are
 // updated.
 //
@@ -0,0 +1,43 @@
+package youtube
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchClaimsParamsBody(t *testing.T) {
+	p := &PatchClaimsParams{
+		ClaimId:               "c1",
+		BlockOutsideOwnership: Ptr(false),
+	}
+	require.True(t, p.Validate(), "an explicit false is a change")
+	body, err := p.Body()
+	require.NoError(t, err)
+	b, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"blockOutsideOwnership":false}`, string(b))
+
+	p = &PatchClaimsParams{
+		ClaimId:     "c1",
+		Status:      ClaimStatusInactive,
+		ContentType: ClaimContentTypeAudio,
+		Policy:      &Policy{Id: "p1"},
+	}
+	require.True(t, p.Validate())
+	body, err = p.Body()
+	require.NoError(t, err)
+	b, err = io.ReadAll(body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"status":"inactive","contentType":"audio","policy":{"id":"p1"}}`, string(b))
+
+	p = &PatchClaimsParams{ClaimId: "c1"}
+	require.False(t, p.Validate(), "nothing to patch")
+	_, err = p.Body()
+	require.ErrorIs(t, err, ErrInvalidPatchClaimsParams)
+
+	require.False(t, (&PatchClaimsParams{BlockOutsideOwnership: Ptr(true)}).Validate(), "missing claim id")
+	require.False(t, (&PatchClaimsParams{ClaimId: "c1", ContentType: "image"}).Validate(), "invalid content type")
+	require.False(t, (&PatchClaimsParams{ClaimId: "c1", Status: "gone"}).Validate(), "invalid status")
+}
@@ -3,10 +3,55 @@ package youtube
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"golang.org/x/oauth2/google"
 )
 
+// ClientOption configures NewClient.
+type ClientOption func(o *clientOptions)
+
+type clientOptions struct {
+	subject     string
+	impersonate string
+	delegates   []string
+	lifetime    time.Duration
+	timeout     time.Duration
+}
+
+// WithSubject sets the user to act as through domain-wide delegation. The service account must be granted
+// domain-wide authority for the requested scopes in the Google Workspace admin console.
+func WithSubject(email string) ClientOption {
+	return func(o *clientOptions) {
+		o.subject = email
+	}
+}
+
+// WithImpersonation makes the client act as the target service account through the IAM credentials API, so that the
+// key passed to NewClient only needs the Service Account Token Creator role rather than the target's privileges.
+// Delegates is the optional chain of intermediate service accounts, in order, between the key's account and target.
+func WithImpersonation(target string, delegates ...string) ClientOption {
+	return func(o *clientOptions) {
+		o.impersonate = target
+		o.delegates = delegates
+	}
+}
+
+// WithImpersonationLifetime sets the lifetime of impersonated access tokens. Defaults to
+// DefaultImpersonationLifetime.
+func WithImpersonationLifetime(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.lifetime = d
+	}
+}
+
+// WithTokenTimeout sets the timeout for token exchanges made while minting impersonated tokens.
+func WithTokenTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = d
+	}
+}
+
 // NewClient creates an authenticated *http.Client from a Google service account
 // JWT key (JSON). The returned client automatically handles token refresh and
 // attaches the Bearer token to every outgoing request.
@@ -21,10 +66,30 @@ import (
 //
 //   - https://www.googleapis.com/auth/youtube
 //   - https://www.googleapis.com/auth/youtube.readonly
-func NewClient(keyJSON []byte, scopes []string) (*http.Client, error) {
-	cfg, err := google.JWTConfigFromJSON(keyJSON, scopes...)
+//
+// Use WithSubject for domain-wide delegation and WithImpersonation to act as
+// another service account. When impersonating, the key is only used to call the
+// IAM credentials API and the subject, if any, is applied to the impersonated
+// account.
+func NewClient(keyJSON []byte, scopes []string, opts ...ClientOption) (*http.Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	ctx := context.Background()
+
+	if o.impersonate == "" {
+		cfg, err := google.JWTConfigFromJSON(keyJSON, scopes...)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Subject = o.subject
+		return cfg.Client(ctx), nil
+	}
+
+	cfg, err := google.JWTConfigFromJSON(keyJSON, string(ScopeCloudPlatform))
 	if err != nil {
 		return nil, err
 	}
-	return cfg.Client(context.Background()), nil
+	return newImpersonatedClient(ctx, cfg.Client(ctx), &o, scopes), nil
 }
@@ -1,25 +1,26 @@
 package youtube
 
-// OAuth2 scopes used by the YouTube Content ID Partner API.
+// OAuth2 scopes used by the YouTube Content ID Partner API. These are untyped
+// aliases of the Scope values in oauth.go, for use with NewClient.
 const (
 	// YoutubepartnerScope grants access to view and manage your assets and
 	// associated content on YouTube.
-	YoutubepartnerScope = "https://www.googleapis.com/auth/youtubepartner"
+	YoutubepartnerScope = string(ScopePartner)
 
 	// YoutubepartnerContentOwnerReadonlyScope grants read-only access to
 	// view content owner account details from YouTube.
-	YoutubepartnerContentOwnerReadonlyScope = "https://www.googleapis.com/auth/youtubepartner-content-owner-readonly"
+	YoutubepartnerContentOwnerReadonlyScope = string(ScopePartnerContentOwnerReadOnly)
 )
 
 // OAuth2 scopes used by the YouTube Data API v3.
 const (
 	// YoutubeScope grants access to manage your YouTube account.
-	YoutubeScope = "https://www.googleapis.com/auth/youtube"
+	YoutubeScope = string(ScopeAccount)
 
 	// YoutubeForceSslScope grants access to manage your YouTube account,
 	// requiring SSL for all requests.
-	YoutubeForceSslScope = "https://www.googleapis.com/auth/youtube.force-ssl"
+	YoutubeForceSslScope = string(ScopeForceSSL)
 
 	// YoutubeReadonlyScope grants read-only access to your YouTube account.
-	YoutubeReadonlyScope = "https://www.googleapis.com/auth/youtube.readonly"
+	YoutubeReadonlyScope = string(ScopeReadOnly)
 )
@@ -0,0 +1,305 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDisputeResponseWindow is the time a content owner has to respond to a dispute or an appeal before the
+	// claim is released automatically, per YouTube's current policy.
+	DefaultDisputeResponseWindow = 30 * 24 * time.Hour
+
+	// DefaultDisputeConcurrency is the number of claims enriched at once.
+	DefaultDisputeConcurrency = 4
+)
+
+var (
+	ErrInvalidDecision = errors.New("invalid decision")
+	ErrMissingDecider  = errors.New("missing decider")
+)
+
+// DisputeDecision is the decision taken on a disputed or appealed claim.
+type DisputeDecision string
+
+const (
+	// DisputeDecisionReinstate reinstates the claim, setting its status back to active.
+	DisputeDecisionReinstate DisputeDecision = "reinstate"
+	// DisputeDecisionRelease releases the claim, setting its status to inactive.
+	DisputeDecisionRelease DisputeDecision = "release"
+)
+
+// Status returns the claim status the decision results in.
+func (d DisputeDecision) Status() ClaimStatus {
+	switch d {
+	case DisputeDecisionReinstate:
+		return ClaimStatusActive
+	case DisputeDecisionRelease:
+		return ClaimStatusInactive
+	}
+	return ""
+}
+
+// DisputeItem is a disputed or appealed claim enriched with everything needed to review it.
+type DisputeItem struct {
+	// Snippet is the claim as returned by SearchClaims.
+	Snippet *ClaimSnippet
+
+	// Claim is the full claim from GetClaim, including its MatchInfo.
+	Claim *Claim
+
+	// History is the claim history from GetClaimHistory.
+	History *ClaimHistory
+
+	// Video is the claimed video with its snippet, if it could be found.
+	Video *Video
+
+	// DisputeReason is the reason the uploader gave for the latest dispute, as found in the history.
+	DisputeReason string
+
+	// DisputeNotes are the notes the uploader added to the latest dispute.
+	DisputeNotes string
+
+	// AppealExplanation is the explanation the uploader gave for the latest appeal.
+	AppealExplanation string
+
+	// UploaderChannelId is the channel id of the uploader of the claimed video.
+	UploaderChannelId string
+
+	// DisputedAt is the time of the latest dispute or appeal, if found in the history.
+	DisputedAt time.Time
+
+	// Deadline is the time by which the dispute must be answered. It is zero if DisputedAt is unknown.
+	Deadline time.Time
+
+	// Views is the number of views of the claimed video.
+	Views int64
+}
+
+// DecisionRecord records a decision taken on a claim.
+type DecisionRecord struct {
+	ClaimId   string          `json:"claimId"`
+	Decision  DisputeDecision `json:"decision"`
+	DecidedBy string          `json:"decidedBy"`
+	Note      string          `json:"note,omitempty"`
+	Previous  ClaimStatus     `json:"previous,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Time      time.Time       `json:"time"`
+}
+
+// DecisionLog records who decided what.
+type DecisionLog interface {
+	Log(r *DecisionRecord) error
+}
+
+// FileDecisionLog appends each decision as a JSON line to the file at Path.
+type FileDecisionLog struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (l *FileDecisionLog) Log(r *DecisionRecord) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// DisputeQueue builds a review queue of disputed and appealed claims and applies decisions to them.
+type DisputeQueue struct {
+	// OnBehalfOfContentOwner identifies the content owner that the user is acting on behalf of.
+	OnBehalfOfContentOwner string
+
+	// Concurrency is the number of claims enriched at once. Defaults to DefaultDisputeConcurrency.
+	Concurrency int
+
+	// ResponseWindow is the time allowed to respond to a dispute or an appeal, used to compute deadlines. Defaults to
+	// DefaultDisputeResponseWindow.
+	ResponseWindow time.Duration
+
+	// Log, if provided, records every decision.
+	Log DecisionLog
+}
+
+// Load collects the disputed and appealed claims and enriches them with their history, match information and video
+// snippet. The queue is ordered by deadline, earliest first, then by views, most viewed first. Items without a known
+// deadline come last.
+func (q *DisputeQueue) Load(ctx context.Context, runner RequestRunner) ([]*DisputeItem, error) {
+	var items []*DisputeItem
+	seen := make(map[string]bool)
+	for _, status := range []ClaimStatus{ClaimStatusDisputed, ClaimStatusAppealed} {
+		p := &SearchClaimsParams{
+			Status:                 status,
+			OnBehalfOfContentOwner: q.OnBehalfOfContentOwner,
+		}
+		for c, err := range SearchClaimsIter(runner, p, PageOptions{}) {
+			if err != nil {
+				return nil, err
+			}
+			if seen[c.Id] {
+				continue
+			}
+			seen[c.Id] = true
+			views, _ := strconv.ParseInt(c.VideoViews, 10, 64)
+			items = append(items, &DisputeItem{
+				Snippet: c,
+				Views:   views,
+			})
+		}
+	}
+
+	if err := q.enrich(ctx, runner, items); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.Deadline.Equal(b.Deadline) {
+			if a.Deadline.IsZero() || b.Deadline.IsZero() {
+				return b.Deadline.IsZero()
+			}
+			return a.Deadline.Before(b.Deadline)
+		}
+		return a.Views > b.Views
+	})
+	return items, nil
+}
+
+// enrich fetches the claim, history and video of each item.
+func (q *DisputeQueue) enrich(ctx context.Context, runner RequestRunner, items []*DisputeItem) error {
+	concurrency := q.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultDisputeConcurrency
+	}
+	window := q.ResponseWindow
+	if window <= 0 {
+		window = DefaultDisputeResponseWindow
+	}
+
+	g, _ := newFanOut(ctx, concurrency)
+	for _, item := range items {
+		g.Go(func() error {
+			claim, err := GetClaim(runner, &GetClaimParams{
+				ClaimId:                item.Snippet.Id,
+				OnBehalfOfContentOwner: q.OnBehalfOfContentOwner,
+			})
+			if err != nil {
+				return err
+			}
+			history, err := GetClaimHistory(runner, &GetClaimHistoryParams{
+				ClaimId:                item.Snippet.Id,
+				OnBehalfOfContentOwner: q.OnBehalfOfContentOwner,
+			})
+			if err != nil {
+				return err
+			}
+			item.Claim = claim
+			item.setHistory(history, window)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	videoIds := make([]string, 0, len(items))
+	for _, item := range items {
+		videoIds = append(videoIds, item.Snippet.VideoId)
+	}
+	videos, err := BulkListVideos(ctx, runner, videoIds, &ListVideoParams{
+		Parts: []ListVideoParamsPart{ListVideoParamsPartSnippet},
+	}, BulkOptions{Concurrency: concurrency})
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		item.Video = videos.ById[item.Snippet.VideoId]
+	}
+	return nil
+}
+
+// setHistory extracts the dispute details from the claim history and sets the deadline window after the latest
+// dispute or appeal.
+func (item *DisputeItem) setHistory(h *ClaimHistory, window time.Duration) {
+	item.History = h
+	item.UploaderChannelId = h.UploaderChannelId
+	for _, e := range h.Event {
+		if e.TypeDetails == nil {
+			continue
+		}
+		d := e.TypeDetails
+		if d.DisputeReason == "" && d.DisputeNotes == "" && d.AppealExplanation == "" {
+			continue
+		}
+		if d.DisputeReason != "" {
+			item.DisputeReason = d.DisputeReason
+		}
+		if d.DisputeNotes != "" {
+			item.DisputeNotes = d.DisputeNotes
+		}
+		if d.AppealExplanation != "" {
+			item.AppealExplanation = d.AppealExplanation
+		}
+		if t, err := e.ParsedTime(); err == nil && t.After(item.DisputedAt) {
+			item.DisputedAt = t
+		}
+	}
+	if !item.DisputedAt.IsZero() {
+		item.Deadline = item.DisputedAt.Add(window)
+	}
+}
+
+// Decide applies a decision to a claim through PatchClaim and records it in the log. The decider identifies who took
+// the decision; the note is free-form. The decision is logged even if the patch fails.
+func (q *DisputeQueue) Decide(runner RequestRunner, item *DisputeItem, decision DisputeDecision, decidedBy, note string) (*Claim, error) {
+	status := decision.Status()
+	if status == "" {
+		return nil, ErrInvalidDecision
+	}
+	if decidedBy == "" {
+		return nil, ErrMissingDecider
+	}
+	r := &DecisionRecord{
+		ClaimId:   item.Snippet.Id,
+		Decision:  decision,
+		DecidedBy: decidedBy,
+		Note:      note,
+		Previous:  ClaimStatus(item.Snippet.Status),
+		Time:      time.Now(),
+	}
+	claim, err := PatchClaim(runner, &PatchClaimsParams{
+		ClaimId:                item.Snippet.Id,
+		OnBehalfOfContentOwner: q.OnBehalfOfContentOwner,
+		Status:                 status,
+	})
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if q.Log != nil {
+		if logErr := q.Log.Log(r); logErr != nil && err == nil {
+			return claim, logErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
@@ -0,0 +1,190 @@
+package youtube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDisputeRunner serves claimSearch by status, claims.get, claims.patch, claimHistory.get and videos.list. Patches
+// of the claims in failPatch return an error.
+type fakeDisputeRunner struct {
+	search    map[ClaimStatus][]*ClaimSnippet
+	histories map[string]*ClaimHistory
+	failPatch map[string]bool
+
+	mu      sync.Mutex
+	patched map[string]ClaimStatus
+}
+
+func (r *fakeDisputeRunner) Run(req *Request) (*http.Response, error) {
+	var out any
+	switch {
+	case req.Url == SearchClaimsUrl:
+		out = ClaimSearchResponse{Items: r.search[ClaimStatus(req.Params.Get("status"))]}
+	case strings.HasPrefix(req.Url, ClaimHistoryUrl+"/"):
+		id := strings.TrimPrefix(req.Url, ClaimHistoryUrl+"/")
+		h, ok := r.histories[id]
+		if !ok {
+			h = &ClaimHistory{Id: id}
+		}
+		out = h
+	case strings.HasPrefix(req.Url, ClaimsUrl+"/"):
+		id := strings.TrimPrefix(req.Url, ClaimsUrl+"/")
+		claim := Claim{Id: id}
+		if req.Method == http.MethodPatch {
+			if r.failPatch[id] {
+				return nil, errors.New("patch failed")
+			}
+			if err := json.NewDecoder(req.Body).Decode(&claim); err != nil {
+				return nil, err
+			}
+			r.mu.Lock()
+			if r.patched == nil {
+				r.patched = make(map[string]ClaimStatus)
+			}
+			r.patched[id] = claim.Status
+			r.mu.Unlock()
+			claim.Id = id
+		}
+		out = claim
+	case req.Url == ListVideosUrl:
+		var videos []*Video
+		for _, id := range strings.Split(req.Params.Get("id"), ",") {
+			videos = append(videos, &Video{Id: id})
+		}
+		out = ListVideosResponse{Items: videos}
+	default:
+		return nil, errors.New("unexpected request to " + req.Url)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(b))}, nil
+}
+
+type memoryDecisionLog struct {
+	records []*DecisionRecord
+}
+
+func (l *memoryDecisionLog) Log(r *DecisionRecord) error {
+	l.records = append(l.records, r)
+	return nil
+}
+
+func disputedAt(t string) *ClaimHistory {
+	return &ClaimHistory{
+		Event: []*ClaimEvent{
+			{Time: t, TypeDetails: &TypeDetails{DisputeReason: "fairUse"}},
+		},
+	}
+}
+
+func TestDisputeQueueLoad(t *testing.T) {
+	runner := &fakeDisputeRunner{
+		search: map[ClaimStatus][]*ClaimSnippet{
+			ClaimStatusDisputed: {
+				{Id: "late", VideoId: "v1", VideoViews: "5"},
+				{Id: "early", VideoId: "v2", VideoViews: "1"},
+				{Id: "unknown", VideoId: "v3", VideoViews: "1000"},
+				{Id: "tieLow", VideoId: "v4", VideoViews: "10"},
+			},
+			ClaimStatusAppealed: {
+				{Id: "tieHigh", VideoId: "v5", VideoViews: "100"},
+				{Id: "early", VideoId: "v2", VideoViews: "1"},
+			},
+		},
+		histories: map[string]*ClaimHistory{
+			"late":    disputedAt("2024-01-10T00:00:00Z"),
+			"early":   disputedAt("2024-01-01T00:00:00Z"),
+			"tieLow":  disputedAt("2024-01-05T00:00:00Z"),
+			"tieHigh": disputedAt("2024-01-05T00:00:00Z"),
+		},
+	}
+	q := &DisputeQueue{ResponseWindow: 48 * time.Hour}
+	items, err := q.Load(context.Background(), runner)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.Snippet.Id)
+		require.Equal(t, item.Snippet.Id, item.Claim.Id)
+		require.Equal(t, item.Snippet.VideoId, item.Video.Id)
+	}
+	require.Equal(t, []string{"early", "tieHigh", "tieLow", "late", "unknown"}, ids)
+
+	require.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), items[0].Deadline)
+	require.True(t, items[4].Deadline.IsZero())
+
+	q.ResponseWindow = 0
+	items, err = q.Load(context.Background(), runner)
+	require.NoError(t, err)
+	require.Equal(t, items[0].DisputedAt.Add(DefaultDisputeResponseWindow), items[0].Deadline)
+}
+
+func TestDisputeItemSetHistory(t *testing.T) {
+	item := &DisputeItem{}
+	item.setHistory(&ClaimHistory{
+		UploaderChannelId: "UC1",
+		Event: []*ClaimEvent{
+			{Time: "2024-01-01T00:00:00Z", Type: "claimCreate"},
+			{Time: "2024-01-02T00:00:00Z", TypeDetails: &TypeDetails{DisputeReason: "fairUse", DisputeNotes: "parody"}},
+			{Time: "2024-01-04T12:00:00Z", TypeDetails: &TypeDetails{AppealExplanation: "still fair use"}},
+			{Time: "2024-01-06T00:00:00Z", Type: "claimUpdate", TypeDetails: &TypeDetails{}},
+		},
+	}, 24*time.Hour)
+
+	require.Equal(t, "UC1", item.UploaderChannelId)
+	require.Equal(t, "fairUse", item.DisputeReason)
+	require.Equal(t, "parody", item.DisputeNotes)
+	require.Equal(t, "still fair use", item.AppealExplanation)
+	require.Equal(t, time.Date(2024, 1, 4, 12, 0, 0, 0, time.UTC), item.DisputedAt)
+	require.Equal(t, time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC), item.Deadline)
+
+	item = &DisputeItem{}
+	item.setHistory(&ClaimHistory{Event: []*ClaimEvent{{Time: "2024-01-01T00:00:00Z", Type: "claimCreate"}}}, time.Hour)
+	require.True(t, item.DisputedAt.IsZero())
+	require.True(t, item.Deadline.IsZero())
+}
+
+func TestDisputeQueueDecide(t *testing.T) {
+	runner := &fakeDisputeRunner{failPatch: map[string]bool{"b": true}}
+	log := &memoryDecisionLog{}
+	q := &DisputeQueue{Log: log}
+
+	claim, err := q.Decide(runner, &DisputeItem{Snippet: &ClaimSnippet{Id: "a", Status: string(ClaimStatusDisputed)}}, DisputeDecisionRelease, "alex", "fair use")
+	require.NoError(t, err)
+	require.Equal(t, ClaimStatusInactive, claim.Status)
+	require.Equal(t, ClaimStatusInactive, runner.patched["a"])
+
+	_, err = q.Decide(runner, &DisputeItem{Snippet: &ClaimSnippet{Id: "b", Status: string(ClaimStatusAppealed)}}, DisputeDecisionReinstate, "alex", "")
+	require.EqualError(t, err, "patch failed")
+
+	require.Len(t, log.records, 2)
+	require.Equal(t, "a", log.records[0].ClaimId)
+	require.Equal(t, DisputeDecisionRelease, log.records[0].Decision)
+	require.Equal(t, "alex", log.records[0].DecidedBy)
+	require.Equal(t, "fair use", log.records[0].Note)
+	require.Equal(t, ClaimStatusDisputed, log.records[0].Previous)
+	require.Empty(t, log.records[0].Error)
+
+	require.Equal(t, "b", log.records[1].ClaimId)
+	require.Equal(t, ClaimStatusAppealed, log.records[1].Previous)
+	require.Equal(t, "patch failed", log.records[1].Error)
+
+	_, err = q.Decide(runner, &DisputeItem{Snippet: &ClaimSnippet{Id: "a"}}, "keep", "alex", "")
+	require.ErrorIs(t, err, ErrInvalidDecision)
+	_, err = q.Decide(runner, &DisputeItem{Snippet: &ClaimSnippet{Id: "a"}}, DisputeDecisionRelease, "", "")
+	require.ErrorIs(t, err, ErrMissingDecider)
+	require.Len(t, log.records, 2, "invalid decisions are not logged")
+}
@@ -3,28 +3,52 @@ package youtube
 import "errors"
 
 var (
-	ErrInvalidAccessType  = errors.New("invalid access type")
-	ErrInvalidPart        = errors.New("invalid part")
-	ErrInvalidPrompt      = errors.New("invalid prompt")
-	ErrInvalidScope       = errors.New("invalid scope")
-	ErrMissingClientId    = errors.New("missing client id")
-	ErrMissingParts       = errors.New("missing parts")
-	ErrMissingRedirectUri = errors.New("missing redirect uri")
-	ErrMissingScopes      = errors.New("missing scopes")
-	ErrNotFound           = errors.New("not found")
+	ErrInvalidAccessType          = errors.New("invalid access type")
+	ErrInvalidCodeChallenge       = errors.New("invalid code challenge")
+	ErrInvalidCodeChallengeMethod = errors.New("invalid code challenge method")
+	ErrInvalidCodeVerifier        = errors.New("invalid code verifier")
+	ErrInvalidPart                = errors.New("invalid part")
+	ErrInvalidPrompt              = errors.New("invalid prompt")
+	ErrInvalidResponseMode        = errors.New("invalid response mode")
+	ErrInvalidScope               = errors.New("invalid scope")
+	ErrInvalidState               = errors.New("invalid state")
+	ErrMissingClientId            = errors.New("missing client id")
+	ErrMissingCode                = errors.New("missing code")
+	ErrMissingCodeChallenge       = errors.New("missing code challenge")
+	ErrMissingCodeVerifier        = errors.New("missing code verifier")
+	ErrMissingDeviceCode          = errors.New("missing device code")
+	ErrMissingParts               = errors.New("missing parts")
+	ErrMissingRedirectUri         = errors.New("missing redirect uri")
+	ErrMissingScopes              = errors.New("missing scopes")
+	ErrNotFound                   = errors.New("not found")
 )
 
 const (
-	ErrAccessDenied        OAuthError = "access_denied"
-	ErrAdminPolicyEnforced OAuthError = "admin_policy_enforced"
-	ErrDisallowedUserAgent OAuthError = "disallowed_useragent"
-	ErrOrgInternal         OAuthError = "org_internal"
-	ErrRedirectUriMismatch OAuthError = "redirect_uri_mismatch"
-
-	ErrTypeBody         ErrorType = "could not read body"
-	ErrTypeInvalidGrant ErrorType = "invalid_grant"
-	ErrTypeJSON         ErrorType = "json_error"
-	ErrTypeUnknown      ErrorType = "unknown"
+	ErrAccessDenied             OAuthError = "access_denied"
+	ErrAccountSelectionRequired OAuthError = "account_selection_required"
+	ErrAdminPolicyEnforced      OAuthError = "admin_policy_enforced"
+	ErrConsentRequired          OAuthError = "consent_required"
+	ErrDisallowedUserAgent      OAuthError = "disallowed_useragent"
+	ErrExpiredToken             OAuthError = "expired_token"
+	ErrInteractionRequired      OAuthError = "interaction_required"
+	ErrInvalidClient            OAuthError = "invalid_client"
+	ErrInvalidRequest           OAuthError = "invalid_request"
+	ErrLoginRequired            OAuthError = "login_required"
+	ErrOrgInternal              OAuthError = "org_internal"
+	ErrRedirectUriMismatch      OAuthError = "redirect_uri_mismatch"
+	ErrServerError              OAuthError = "server_error"
+	ErrTemporarilyUnavailable   OAuthError = "temporarily_unavailable"
+	ErrUnauthorizedClient       OAuthError = "unauthorized_client"
+	ErrUnsupportedResponseType  OAuthError = "unsupported_response_type"
+
+	ErrTypeAccessDenied         ErrorType = "access_denied"
+	ErrTypeAuthorizationPending ErrorType = "authorization_pending"
+	ErrTypeBody                 ErrorType = "could not read body"
+	ErrTypeExpiredToken         ErrorType = "expired_token"
+	ErrTypeInvalidGrant         ErrorType = "invalid_grant"
+	ErrTypeJSON                 ErrorType = "json_error"
+	ErrTypeSlowDown             ErrorType = "slow_down"
+	ErrTypeUnknown              ErrorType = "unknown"
 )
 
 type OAuthError string
@@ -0,0 +1,71 @@
+package youtube
+
+import (
+	"context"
+	"sync"
+)
+
+// fanOut runs tasks concurrently with at most a fixed number running at once. The first task error cancels the context
+// of the group, so that tasks which have not started yet are skipped and running tasks can stop early.
+//
+// Go never blocks, so a running task may start further tasks without holding up its own slot.
+type fanOut struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// newFanOut creates a group whose context is derived from ctx. Tasks should watch the returned context, which is
+// cancelled once Wait returns.
+func newFanOut(ctx context.Context, concurrency int) (*fanOut, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &fanOut{
+		ctx:    ctx,
+		cancel: cancel,
+		sem:    make(chan struct{}, max(concurrency, 1)),
+	}, ctx
+}
+
+// Go runs task once a slot is free. The task is skipped if the context is done by then.
+func (f *fanOut) Go(task func() error) {
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		select {
+		case f.sem <- struct{}{}:
+		case <-f.ctx.Done():
+			return
+		}
+		defer func() { <-f.sem }()
+		if f.ctx.Err() != nil {
+			return
+		}
+		if err := task(); err != nil {
+			f.fail(err)
+		}
+	}()
+}
+
+func (f *fanOut) fail(err error) {
+	f.mu.Lock()
+	if f.err == nil {
+		f.err = err
+	}
+	f.mu.Unlock()
+	f.cancel()
+}
+
+// Wait waits for every started task and returns the first task error, or the error of the parent context if it was
+// cancelled.
+func (f *fanOut) Wait() error {
+	f.wg.Wait()
+	defer f.cancel()
+	if f.err != nil {
+		return f.err
+	}
+	return f.ctx.Err()
+}
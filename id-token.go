@@ -0,0 +1,258 @@
+package youtube
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2/jws"
+)
+
+const (
+	GoogleJwksUrl = "https://www.googleapis.com/oauth2/v3/certs"
+
+	// DefaultJwksCacheDuration is how long fetched keys are cached when the response has no usable max-age.
+	DefaultJwksCacheDuration = time.Hour
+
+	// DefaultIdTokenClockSkew is the leeway applied when checking the expiry of an ID token.
+	DefaultIdTokenClockSkew = time.Minute
+)
+
+var (
+	ErrExpiredIdToken      = errors.New("expired id token")
+	ErrInvalidAudience     = errors.New("invalid audience")
+	ErrInvalidHostedDomain = errors.New("invalid hosted domain")
+	ErrInvalidIdToken      = errors.New("invalid id token")
+	ErrInvalidIssuer       = errors.New("invalid issuer")
+	ErrUnknownKeyId        = errors.New("unknown key id")
+
+	// GoogleIssuers are the issuers Google uses in ID tokens.
+	GoogleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+)
+
+// KeySource provides the public keys used to verify ID token signatures, keyed by key id.
+type KeySource interface {
+	Keys() (map[string]*rsa.PublicKey, error)
+}
+
+// StaticKeySource is a KeySource over a fixed set of keys, useful for offline tests.
+type StaticKeySource map[string]*rsa.PublicKey
+
+func (s StaticKeySource) Keys() (map[string]*rsa.PublicKey, error) {
+	return s, nil
+}
+
+// JwksKeySource fetches keys from a JWKS endpoint and caches them according to the max-age of the response.
+type JwksKeySource struct {
+	// Url of the JWKS document. Defaults to GoogleJwksUrl.
+	Url string
+
+	// Timeout for fetching the keys.
+	Timeout time.Duration
+
+	mu     sync.Mutex
+	keys   map[string]*rsa.PublicKey
+	expiry time.Time
+}
+
+type jwks struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func (s *JwksKeySource) Keys() (map[string]*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys != nil && time.Now().Before(s.expiry) {
+		return s.keys, nil
+	}
+
+	u := s.Url
+	if u == "" {
+		u = GoogleJwksUrl
+	}
+	runner := &UnauthenticatedRunner{
+		Timeout: s.Timeout,
+	}
+	res, err := runner.Run(&Request{
+		Method: http.MethodGet,
+		Url:    u,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var set jwks
+	if err := DecodeResponse(res, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	s.keys = keys
+	s.expiry = time.Now().Add(maxAge(res.Header.Get("Cache-Control")))
+	return keys, nil
+}
+
+// maxAge returns the max-age of a Cache-Control header, or DefaultJwksCacheDuration if there is none.
+func maxAge(cacheControl string) time.Duration {
+	for _, d := range strings.Split(cacheControl, ",") {
+		v, ok := strings.CutPrefix(strings.TrimSpace(d), "max-age=")
+		if !ok {
+			continue
+		}
+		secs, err := strconv.Atoi(v)
+		if err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return DefaultJwksCacheDuration
+}
+
+// IdTokenClaims are the claims of a Google ID token.
+//
+// @see https://developers.google.com/identity/openid-connect/openid-connect#an-id-tokens-payload
+type IdTokenClaims struct {
+	Issuer          string `json:"iss"`
+	Subject         string `json:"sub"`
+	Audience        string `json:"aud"`
+	AuthorizedParty string `json:"azp"`
+	IssuedAt        int64  `json:"iat"`
+	ExpiresAt       int64  `json:"exp"`
+	Nonce           string `json:"nonce"`
+	Hd              string `json:"hd"`
+	Email           string `json:"email"`
+	EmailVerified   bool   `json:"email_verified"`
+	Name            string `json:"name"`
+	GivenName       string `json:"given_name"`
+	FamilyName      string `json:"family_name"`
+	Picture         string `json:"picture"`
+}
+
+// Expiry returns the time at which the ID token expires.
+func (c *IdTokenClaims) Expiry() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// UserInfo converts the claims to the UserInfo returned by GetUserInfo.
+func (c *IdTokenClaims) UserInfo() *UserInfo {
+	return &UserInfo{
+		Picture:       c.Picture,
+		VerifiedEmail: c.EmailVerified,
+		Id:            c.Subject,
+		Hd:            c.Hd,
+		Email:         c.Email,
+		Name:          c.Name,
+		GivenName:     c.GivenName,
+	}
+}
+
+// IdTokenVerifier verifies Google ID tokens, such as Token.IdToken, without a call to the userinfo endpoint.
+type IdTokenVerifier struct {
+	// ClientIds are the accepted audiences. This is required.
+	ClientIds []string
+
+	// HostedDomain, if set, requires the hd claim to match, restricting sign in to a Google Workspace domain.
+	HostedDomain string
+
+	// Keys provides the verification keys. Defaults to a JwksKeySource on GoogleJwksUrl.
+	Keys KeySource
+
+	// ClockSkew is the leeway applied to the expiry check. Defaults to DefaultIdTokenClockSkew.
+	ClockSkew time.Duration
+
+	once        sync.Once
+	defaultKeys KeySource
+}
+
+// Verify checks the signature, issuer, audience, expiry and hosted domain of the ID token and returns its claims.
+func (v *IdTokenVerifier) Verify(idToken string) (*IdTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidIdToken
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidIdToken
+	}
+	var header jws.Header
+	if err := json.Unmarshal(rawHeader, &header); err != nil || header.Algorithm != "RS256" {
+		return nil, ErrInvalidIdToken
+	}
+
+	keys, err := v.keySource().Keys()
+	if err != nil {
+		return nil, err
+	}
+	key, ok := keys[header.KeyID]
+	if !ok {
+		return nil, ErrUnknownKeyId
+	}
+	if err := jws.Verify(idToken, key); err != nil {
+		return nil, ErrInvalidIdToken
+	}
+
+	rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidIdToken
+	}
+	var c IdTokenClaims
+	if err := json.Unmarshal(rawClaims, &c); err != nil {
+		return nil, ErrInvalidIdToken
+	}
+
+	if !slices.Contains(GoogleIssuers, c.Issuer) {
+		return nil, ErrInvalidIssuer
+	}
+	if !slices.Contains(v.ClientIds, c.Audience) {
+		return nil, ErrInvalidAudience
+	}
+	skew := v.ClockSkew
+	if skew <= 0 {
+		skew = DefaultIdTokenClockSkew
+	}
+	if time.Now().Add(-skew).After(c.Expiry()) {
+		return nil, ErrExpiredIdToken
+	}
+	if v.HostedDomain != "" && c.Hd != v.HostedDomain {
+		return nil, ErrInvalidHostedDomain
+	}
+	return &c, nil
+}
+
+func (v *IdTokenVerifier) keySource() KeySource {
+	if v.Keys != nil {
+		return v.Keys
+	}
+	v.once.Do(func() {
+		v.defaultKeys = &JwksKeySource{}
+	})
+	return v.defaultKeys
+}
@@ -0,0 +1,95 @@
+package youtube
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// signIdToken signs the claims with the key, using the alg and kid given in the header.
+func signIdToken(t *testing.T, key *rsa.PrivateKey, alg, kid string, claims *IdTokenClaims) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	require.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestIdTokenVerifier(t *testing.T) {
+	key, _ := newTestKey(t)
+	other, _ := newTestKey(t)
+	v := &IdTokenVerifier{
+		ClientIds:    []string{"client"},
+		HostedDomain: "example.com",
+		Keys:         StaticKeySource{"k1": &key.PublicKey},
+		ClockSkew:    time.Minute,
+	}
+	valid := func() *IdTokenClaims {
+		return &IdTokenClaims{
+			Issuer:    "https://accounts.google.com",
+			Subject:   "123",
+			Audience:  "client",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+			Hd:        "example.com",
+			Email:     "user@example.com",
+		}
+	}
+
+	c, err := v.Verify(signIdToken(t, key, "RS256", "k1", valid()))
+	require.NoError(t, err)
+	require.Equal(t, "123", c.Subject)
+
+	// Expired, but within the clock skew.
+	claims := valid()
+	claims.ExpiresAt = time.Now().Add(-30 * time.Second).Unix()
+	_, err = v.Verify(signIdToken(t, key, "RS256", "k1", claims))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		token string
+		err   error
+	}{
+		"bad signature": {signIdToken(t, other, "RS256", "k1", valid()), ErrInvalidIdToken},
+		"unknown kid":   {signIdToken(t, key, "RS256", "k2", valid()), ErrUnknownKeyId},
+		"non RS256":     {signIdToken(t, key, "HS256", "k1", valid()), ErrInvalidIdToken},
+		"malformed":     {"a.b", ErrInvalidIdToken},
+		"wrong iss": {signIdToken(t, key, "RS256", "k1", func() *IdTokenClaims {
+			c := valid()
+			c.Issuer = "https://evil.example.com"
+			return c
+		}()), ErrInvalidIssuer},
+		"wrong aud": {signIdToken(t, key, "RS256", "k1", func() *IdTokenClaims {
+			c := valid()
+			c.Audience = "other-client"
+			return c
+		}()), ErrInvalidAudience},
+		"expired beyond skew": {signIdToken(t, key, "RS256", "k1", func() *IdTokenClaims {
+			c := valid()
+			c.ExpiresAt = time.Now().Add(-2 * time.Minute).Unix()
+			return c
+		}()), ErrExpiredIdToken},
+		"hd mismatch": {signIdToken(t, key, "RS256", "k1", func() *IdTokenClaims {
+			c := valid()
+			c.Hd = "other.com"
+			return c
+		}()), ErrInvalidHostedDomain},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := v.Verify(tt.token)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
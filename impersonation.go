@@ -0,0 +1,246 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	IAMCredentialsUrl = "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts"
+
+	// ScopeCloudPlatform is the scope the source credentials need to call the IAM credentials API.
+	ScopeCloudPlatform Scope = "https://www.googleapis.com/auth/cloud-platform"
+
+	// DefaultImpersonationLifetime is the lifetime requested for impersonated access tokens.
+	DefaultImpersonationLifetime = time.Hour
+)
+
+var (
+	ErrMissingServiceAccount = errors.New("missing service account")
+
+	// iamCredentialsUrl is the base url of the IAM credentials API. It is a variable so that tests can replace it with
+	// a local server.
+	iamCredentialsUrl = IAMCredentialsUrl
+)
+
+// GenerateAccessTokenParams are parameters for the IAM credentials generateAccessToken method, which creates an
+// access token for a service account the caller is allowed to impersonate.
+//
+// see https://cloud.google.com/iam/docs/reference/credentials/rest/v1/projects.serviceAccounts/generateAccessToken
+type GenerateAccessTokenParams struct {
+	// ServiceAccount is the email of the service account to impersonate.
+	ServiceAccount string
+
+	// Delegates is the delegation chain of service account emails. Each account must be granted the Service Account
+	// Token Creator role on the next, with the last one having it on ServiceAccount.
+	Delegates []string
+
+	// Scopes requested for the access token.
+	Scopes []string
+
+	// Lifetime of the access token. Defaults to DefaultImpersonationLifetime.
+	Lifetime time.Duration
+}
+
+type generateAccessTokenRequest struct {
+	Delegates []string `json:"delegates,omitempty"`
+	Scope     []string `json:"scope"`
+	Lifetime  string   `json:"lifetime,omitempty"`
+}
+
+type generateAccessTokenResponse struct {
+	AccessToken string    `json:"accessToken"`
+	ExpireTime  time.Time `json:"expireTime"`
+}
+
+// GenerateAccessToken creates a short-lived access token for the impersonated service account. The runner must be
+// authenticated with credentials holding ScopeCloudPlatform.
+//
+// see https://cloud.google.com/iam/docs/reference/credentials/rest/v1/projects.serviceAccounts/generateAccessToken
+func GenerateAccessToken(runner RequestRunner, p *GenerateAccessTokenParams) (*Token, error) {
+	if p.ServiceAccount == "" {
+		return nil, ErrMissingServiceAccount
+	}
+	lifetime := p.Lifetime
+	if lifetime <= 0 {
+		lifetime = DefaultImpersonationLifetime
+	}
+	body, err := jsonBody(&generateAccessTokenRequest{
+		Delegates: serviceAccountResources(p.Delegates),
+		Scope:     p.Scopes,
+		Lifetime:  strconv.Itoa(int(lifetime.Seconds())) + "s",
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := runner.Run(&Request{
+		Method: http.MethodPost,
+		Url:    iamCredentialsUrl + "/" + p.ServiceAccount + ":generateAccessToken",
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out generateAccessTokenResponse
+	if err := DecodeResponse(res, &out); err != nil {
+		return nil, err
+	}
+	return &Token{
+		AccessToken:   out.AccessToken,
+		ExpiresInSecs: int(time.Until(out.ExpireTime).Seconds()),
+		TokenType:     "Bearer",
+		Expiry:        out.ExpireTime,
+	}, nil
+}
+
+// SignJwtParams are parameters for the IAM credentials signJwt method, which signs a JWT with the system-managed key
+// of a service account the caller is allowed to impersonate.
+//
+// see https://cloud.google.com/iam/docs/reference/credentials/rest/v1/projects.serviceAccounts/signJwt
+type SignJwtParams struct {
+	// ServiceAccount is the email of the service account whose key signs the JWT.
+	ServiceAccount string
+
+	// Delegates is the delegation chain of service account emails. See GenerateAccessTokenParams.Delegates.
+	Delegates []string
+
+	// Claims is the JWT claim set to sign.
+	Claims map[string]interface{}
+}
+
+type signJwtRequest struct {
+	Delegates []string `json:"delegates,omitempty"`
+	Payload   string   `json:"payload"`
+}
+
+type signJwtResponse struct {
+	KeyId     string `json:"keyId"`
+	SignedJwt string `json:"signedJwt"`
+}
+
+// SignJwt signs the claims as the impersonated service account and returns the encoded JWT. The runner must be
+// authenticated with credentials holding ScopeCloudPlatform.
+//
+// see https://cloud.google.com/iam/docs/reference/credentials/rest/v1/projects.serviceAccounts/signJwt
+func SignJwt(runner RequestRunner, p *SignJwtParams) (string, error) {
+	if p.ServiceAccount == "" {
+		return "", ErrMissingServiceAccount
+	}
+	payload, err := json.Marshal(p.Claims)
+	if err != nil {
+		return "", err
+	}
+	body, err := jsonBody(&signJwtRequest{
+		Delegates: serviceAccountResources(p.Delegates),
+		Payload:   string(payload),
+	})
+	if err != nil {
+		return "", err
+	}
+	res, err := runner.Run(&Request{
+		Method: http.MethodPost,
+		Url:    iamCredentialsUrl + "/" + p.ServiceAccount + ":signJwt",
+		Body:   body,
+	})
+	if err != nil {
+		return "", err
+	}
+	var out signJwtResponse
+	if err := DecodeResponse(res, &out); err != nil {
+		return "", err
+	}
+	return out.SignedJwt, nil
+}
+
+// serviceAccountResources converts service account emails to the resource names expected by the IAM credentials API.
+func serviceAccountResources(emails []string) []string {
+	if len(emails) == 0 {
+		return nil
+	}
+	r := make([]string, 0, len(emails))
+	for _, e := range emails {
+		r = append(r, "projects/-/serviceAccounts/"+e)
+	}
+	return r
+}
+
+// impersonatedTokenSource mints access tokens for an impersonated service account. Without a subject, tokens are
+// created through generateAccessToken. With a subject, a domain-wide delegation JWT is signed through signJwt and
+// exchanged through ExchangeJwtToken, as generateAccessToken cannot set a subject.
+type impersonatedTokenSource struct {
+	runner    RequestRunner
+	target    string
+	delegates []string
+	scopes    []string
+	subject   string
+	lifetime  time.Duration
+	timeout   time.Duration
+}
+
+func (s *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	var (
+		t   *Token
+		err error
+	)
+	if s.subject == "" {
+		t, err = GenerateAccessToken(s.runner, &GenerateAccessTokenParams{
+			ServiceAccount: s.target,
+			Delegates:      s.delegates,
+			Scopes:         s.scopes,
+			Lifetime:       s.lifetime,
+		})
+	} else {
+		t, err = s.delegatedToken()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{
+		AccessToken: t.AccessToken,
+		TokenType:   t.TokenType,
+		Expiry:      t.Expiry,
+	}, nil
+}
+
+func (s *impersonatedTokenSource) delegatedToken() (*Token, error) {
+	// Reverting time back for machines whose clocks are slightly ahead of Google's.
+	now := time.Now().Add(-10 * time.Second)
+	jwt, err := SignJwt(s.runner, &SignJwtParams{
+		ServiceAccount: s.target,
+		Delegates:      s.delegates,
+		Claims: map[string]interface{}{
+			"iss":   s.target,
+			"sub":   s.subject,
+			"scope": strings.Join(s.scopes, " "),
+			"aud":   tokenUrl,
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ExchangeJwtToken(jwt, s.timeout)
+}
+
+// newImpersonatedClient creates an *http.Client authenticated as the impersonated service account, using source as the
+// caller's credentials.
+func newImpersonatedClient(ctx context.Context, source *http.Client, o *clientOptions, scopes []string) *http.Client {
+	ts := &impersonatedTokenSource{
+		runner:    &CustomClientRunner{Client: source},
+		target:    o.impersonate,
+		delegates: o.delegates,
+		scopes:    scopes,
+		subject:   o.subject,
+		lifetime:  o.lifetime,
+		timeout:   o.timeout,
+	}
+	return oauth2.NewClient(ctx, oauth2.ReuseTokenSource(nil, ts))
+}
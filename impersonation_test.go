@@ -0,0 +1,177 @@
+package youtube
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIAM is a local IAM credentials API and token endpoint which records the requests it receives.
+type fakeIAM struct {
+	mu       sync.Mutex
+	paths    []string
+	bodies   []map[string]any
+	exchange url.Values
+}
+
+func newFakeIAM(t *testing.T) *fakeIAM {
+	t.Helper()
+	f := &fakeIAM{}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+
+	oldIam, oldToken := iamCredentialsUrl, tokenUrl
+	iamCredentialsUrl = srv.URL + "/v1/projects/-/serviceAccounts"
+	tokenUrl = srv.URL + "/token"
+	t.Cleanup(func() { iamCredentialsUrl, tokenUrl = oldIam, oldToken })
+	return f
+}
+
+func (f *fakeIAM) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, r.URL.Path)
+
+	if r.URL.Path == "/token" {
+		f.exchange = r.URL.Query()
+		writeJson(w, http.StatusOK, Token{AccessToken: "delegated", ExpiresInSecs: 3600, TokenType: "Bearer"})
+		return
+	}
+
+	b, _ := io.ReadAll(r.Body)
+	var body map[string]any
+	json.Unmarshal(b, &body)
+	f.bodies = append(f.bodies, body)
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, ":generateAccessToken"):
+		writeJson(w, http.StatusOK, map[string]string{
+			"accessToken": "impersonated",
+			"expireTime":  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		})
+	case strings.HasSuffix(r.URL.Path, ":signJwt"):
+		writeJson(w, http.StatusOK, map[string]string{"keyId": "kid", "signedJwt": "signed.jwt.value"})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestGenerateAccessToken(t *testing.T) {
+	f := newFakeIAM(t)
+	runner := &UnauthenticatedRunner{Timeout: time.Second}
+
+	_, err := GenerateAccessToken(runner, &GenerateAccessTokenParams{})
+	require.ErrorIs(t, err, ErrMissingServiceAccount)
+
+	tok, err := GenerateAccessToken(runner, &GenerateAccessTokenParams{
+		ServiceAccount: "target@project.iam.gserviceaccount.com",
+		Delegates:      []string{"first@project.iam.gserviceaccount.com", "second@project.iam.gserviceaccount.com"},
+		Scopes:         []string{string(ScopePartner)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "impersonated", tok.AccessToken)
+	require.Equal(t, "Bearer", tok.TokenType)
+	require.WithinDuration(t, time.Now().Add(time.Hour), tok.Expiry, time.Minute)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	require.Equal(t, []string{"/v1/projects/-/serviceAccounts/target@project.iam.gserviceaccount.com:generateAccessToken"}, f.paths)
+	require.Equal(t, map[string]any{
+		"delegates": []any{
+			"projects/-/serviceAccounts/first@project.iam.gserviceaccount.com",
+			"projects/-/serviceAccounts/second@project.iam.gserviceaccount.com",
+		},
+		"scope":    []any{string(ScopePartner)},
+		"lifetime": "3600s",
+	}, f.bodies[0])
+}
+
+func TestSignJwt(t *testing.T) {
+	f := newFakeIAM(t)
+	runner := &UnauthenticatedRunner{Timeout: time.Second}
+
+	jwt, err := SignJwt(runner, &SignJwtParams{
+		ServiceAccount: "target@project.iam.gserviceaccount.com",
+		Claims:         map[string]interface{}{"sub": "user@example.com"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "signed.jwt.value", jwt)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	require.Equal(t, []string{"/v1/projects/-/serviceAccounts/target@project.iam.gserviceaccount.com:signJwt"}, f.paths)
+	require.NotContains(t, f.bodies[0], "delegates")
+	require.JSONEq(t, `{"sub":"user@example.com"}`, f.bodies[0]["payload"].(string))
+}
+
+func TestImpersonatedClient(t *testing.T) {
+	f := newFakeIAM(t)
+
+	var auth string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer api.Close()
+
+	var o clientOptions
+	WithImpersonation("target@project.iam.gserviceaccount.com")(&o)
+	WithImpersonationLifetime(30 * time.Minute)(&o)
+	client := newImpersonatedClient(context.Background(), http.DefaultClient, &o, []string{string(ScopePartner)})
+
+	res, err := client.Get(api.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, "Bearer impersonated", auth)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	require.Equal(t, "1800s", f.bodies[0]["lifetime"])
+}
+
+func TestImpersonatedClientWithSubject(t *testing.T) {
+	f := newFakeIAM(t)
+
+	var auth string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer api.Close()
+
+	var o clientOptions
+	WithImpersonation("target@project.iam.gserviceaccount.com", "delegate@project.iam.gserviceaccount.com")(&o)
+	WithSubject("user@example.com")(&o)
+	WithTokenTimeout(time.Second)(&o)
+	client := newImpersonatedClient(context.Background(), http.DefaultClient, &o, []string{string(ScopePartner), string(ScopeReadOnly)})
+
+	res, err := client.Get(api.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, "Bearer delegated", auth)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// With a subject, the JWT is signed by the impersonated account and exchanged, as generateAccessToken cannot
+	// set a subject.
+	require.Equal(t, []string{"/v1/projects/-/serviceAccounts/target@project.iam.gserviceaccount.com:signJwt", "/token"}, f.paths)
+	require.Equal(t, []any{"projects/-/serviceAccounts/delegate@project.iam.gserviceaccount.com"}, f.bodies[0]["delegates"])
+
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal([]byte(f.bodies[0]["payload"].(string)), &claims))
+	require.Equal(t, "target@project.iam.gserviceaccount.com", claims["iss"])
+	require.Equal(t, "user@example.com", claims["sub"])
+	require.Equal(t, string(ScopePartner)+" "+string(ScopeReadOnly), claims["scope"])
+	require.Equal(t, tokenUrl, claims["aud"])
+	require.Equal(t, 3600.0, claims["exp"].(float64)-claims["iat"].(float64))
+
+	require.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", f.exchange.Get("grant_type"))
+	require.Equal(t, "signed.jwt.value", f.exchange.Get("assertion"))
+}
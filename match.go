@@ -0,0 +1,294 @@
+package youtube
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Channels of a match segment.
+const (
+	MatchChannelAudio = "audio"
+	MatchChannelVideo = "video"
+)
+
+// MatchInterval is a matched span of a video or a reference, measured from its beginning.
+type MatchInterval struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+func (i MatchInterval) Duration() time.Duration {
+	return i.End - i.Start
+}
+
+func (i MatchInterval) String() string {
+	return FormatDuration(i.Start) + "-" + FormatDuration(i.End)
+}
+
+// MergeIntervals sorts the intervals and merges the ones which overlap or touch. Empty intervals are dropped.
+func MergeIntervals(intervals []MatchInterval) []MatchInterval {
+	sorted := make([]MatchInterval, 0, len(intervals))
+	for _, i := range intervals {
+		if i.End > i.Start {
+			sorted = append(sorted, i)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Start < sorted[b].Start
+	})
+	var out []MatchInterval
+	for _, i := range sorted {
+		if n := len(out); n > 0 && i.Start <= out[n-1].End {
+			out[n-1].End = max(out[n-1].End, i.End)
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// TotalDuration returns the summed length of the intervals. Overlapping intervals should be merged first.
+func TotalDuration(intervals []MatchInterval) time.Duration {
+	var d time.Duration
+	for _, i := range intervals {
+		d += i.Duration()
+	}
+	return d
+}
+
+// FormatDuration formats a duration as m:ss, or h:mm:ss when it is an hour or longer. Fractions of a second are
+// truncated.
+func FormatDuration(d time.Duration) string {
+	s := int64(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+func parseSeconds(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(v) * time.Second, nil
+}
+
+func parseMillis(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(v) * time.Millisecond, nil
+}
+
+// Length returns the duration of the longest match.
+func (m *LongestMatch) Length() (time.Duration, error) {
+	return parseSeconds(m.DurationSecs)
+}
+
+// ReferenceStart returns the offset into the reference at which the longest match starts.
+func (m *LongestMatch) ReferenceStart() (time.Duration, error) {
+	return parseSeconds(m.ReferenceOffset)
+}
+
+// UserVideoStart returns the offset into the user video at which the longest match starts.
+func (m *LongestMatch) UserVideoStart() (time.Duration, error) {
+	return parseSeconds(m.UserVideoOffset)
+}
+
+// ReferenceDuration returns the total amount of the reference which matched the user video.
+func (m *TotalMatch) ReferenceDuration() (time.Duration, error) {
+	return parseSeconds(m.ReferenceDurationSecs)
+}
+
+// UserVideoDuration returns the total amount of the user video which matched the reference.
+func (m *TotalMatch) UserVideoDuration() (time.Duration, error) {
+	return parseSeconds(m.UserVideoDurationSecs)
+}
+
+// Interval returns the span of the segment. Start and duration are in milliseconds.
+func (s *Segment) Interval() (MatchInterval, error) {
+	start, err := parseMillis(s.Start)
+	if err != nil {
+		return MatchInterval{}, err
+	}
+	d, err := parseMillis(s.Duration)
+	if err != nil {
+		return MatchInterval{}, err
+	}
+	return MatchInterval{Start: start, End: start + d}, nil
+}
+
+// Interval returns the span of the manual segment. Start and duration are in seconds.
+func (s *Segment2) Interval() MatchInterval {
+	start := time.Duration(s.Start * float64(time.Second))
+	return MatchInterval{Start: start, End: start + time.Duration(s.Duration*float64(time.Second))}
+}
+
+// VideoInterval returns the span of the user video covered by the segment, falling back to the manual segment.
+func (s *MatchSegment) VideoInterval() (MatchInterval, bool, error) {
+	if s.VideoSegment != nil {
+		i, err := s.VideoSegment.Interval()
+		return i, err == nil, err
+	}
+	if s.ManualSegment != nil {
+		return s.ManualSegment.Interval(), true, nil
+	}
+	return MatchInterval{}, false, nil
+}
+
+// ReferenceInterval returns the span of the reference covered by the segment.
+func (s *MatchSegment) ReferenceInterval() (MatchInterval, bool, error) {
+	if s.ReferenceSegment == nil {
+		return MatchInterval{}, false, nil
+	}
+	i, err := s.ReferenceSegment.Interval()
+	return i, err == nil, err
+}
+
+// ByChannel groups the match segments by channel.
+func (m *MatchInfo) ByChannel() map[string][]*MatchSegment {
+	out := make(map[string][]*MatchSegment)
+	for _, s := range m.MatchSegments {
+		out[s.Channel] = append(out[s.Channel], s)
+	}
+	return out
+}
+
+// VideoIntervals returns the merged spans of the user video that matched. An empty channel includes all channels.
+func (m *MatchInfo) VideoIntervals(channel string) ([]MatchInterval, error) {
+	return m.intervals(channel, (*MatchSegment).VideoInterval)
+}
+
+// ReferenceIntervals returns the merged spans of the reference that matched. An empty channel includes all channels.
+func (m *MatchInfo) ReferenceIntervals(channel string) ([]MatchInterval, error) {
+	return m.intervals(channel, (*MatchSegment).ReferenceInterval)
+}
+
+func (m *MatchInfo) intervals(channel string, fn func(*MatchSegment) (MatchInterval, bool, error)) ([]MatchInterval, error) {
+	var out []MatchInterval
+	for _, s := range m.MatchSegments {
+		if channel != "" && s.Channel != channel {
+			continue
+		}
+		i, ok, err := fn(s)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out = append(out, i)
+		}
+	}
+	return MergeIntervals(out), nil
+}
+
+// MatchCoverage is how much of a video or reference matched.
+type MatchCoverage struct {
+	Matched time.Duration
+	Length  time.Duration
+}
+
+// Percent returns the matched share of the length, between 0 and 100.
+func (c MatchCoverage) Percent() float64 {
+	if c.Length <= 0 {
+		return 0
+	}
+	return min(100, float64(c.Matched)/float64(c.Length)*100)
+}
+
+// String formats the coverage as e.g. "3:12 of 3:30 (91.4%)".
+func (c MatchCoverage) String() string {
+	return fmt.Sprintf("%s of %s (%.1f%%)", FormatDuration(c.Matched), FormatDuration(c.Length), c.Percent())
+}
+
+// VideoCoverage returns how much of the user video, of the given length, matched. The matched duration is taken from
+// the segments, or from TotalMatch when there are none.
+func (m *MatchInfo) VideoCoverage(length time.Duration) (MatchCoverage, error) {
+	return m.coverage(length, m.VideoIntervals, func(t *TotalMatch) (time.Duration, error) {
+		return t.UserVideoDuration()
+	})
+}
+
+// ReferenceCoverage returns how much of the reference, of the given length, matched. The matched duration is taken
+// from the segments, or from TotalMatch when there are none.
+func (m *MatchInfo) ReferenceCoverage(length time.Duration) (MatchCoverage, error) {
+	return m.coverage(length, m.ReferenceIntervals, func(t *TotalMatch) (time.Duration, error) {
+		return t.ReferenceDuration()
+	})
+}
+
+func (m *MatchInfo) coverage(
+	length time.Duration,
+	intervals func(string) ([]MatchInterval, error),
+	total func(*TotalMatch) (time.Duration, error),
+) (MatchCoverage, error) {
+	c := MatchCoverage{Length: length}
+	is, err := intervals("")
+	if err != nil {
+		return c, err
+	}
+	if len(is) > 0 {
+		c.Matched = TotalDuration(is)
+		return c, nil
+	}
+	if m.TotalMatch != nil {
+		c.Matched, err = total(m.TotalMatch)
+	}
+	return c, err
+}
+
+// RenderTimeline renders the matched spans of the user video, one line per channel, as a bar of the given width where
+// '#' marks matched time and '-' unmatched time, e.g. "audio |--####----| 0:30-1:15".
+func (m *MatchInfo) RenderTimeline(length time.Duration, width int) (string, error) {
+	channels := make([]string, 0)
+	for c := range m.ByChannel() {
+		channels = append(channels, c)
+	}
+	sort.Strings(channels)
+
+	var pad int
+	for _, c := range channels {
+		pad = max(pad, len(c))
+	}
+
+	var b strings.Builder
+	for _, c := range channels {
+		is, err := m.VideoIntervals(c)
+		if err != nil {
+			return "", err
+		}
+		spans := make([]string, len(is))
+		for n, i := range is {
+			spans[n] = i.String()
+		}
+		fmt.Fprintf(&b, "%-*s |%s| %s\n", pad, c, RenderIntervals(is, length, width), strings.Join(spans, ", "))
+	}
+	return b.String(), nil
+}
+
+// RenderIntervals renders intervals over the given length as a bar of the given width, where '#' marks a cell that
+// overlaps an interval and '-' one that does not.
+func RenderIntervals(intervals []MatchInterval, length time.Duration, width int) string {
+	if width <= 0 || length <= 0 {
+		return ""
+	}
+	bar := []byte(strings.Repeat("-", width))
+	for _, i := range intervals {
+		from := int(int64(i.Start) * int64(width) / int64(length))
+		to := int((int64(i.End)*int64(width) + int64(length) - 1) / int64(length))
+		for n := max(from, 0); n < min(to, width); n++ {
+			bar[n] = '#'
+		}
+	}
+	return string(bar)
+}
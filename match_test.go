@@ -0,0 +1,28 @@
+package youtube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchInfoCoverage(t *testing.T) {
+	m := &MatchInfo{
+		MatchSegments: []*MatchSegment{
+			{Channel: MatchChannelAudio, VideoSegment: &Segment{Start: "0", Duration: "120000"}, ReferenceSegment: &Segment{Start: "0", Duration: "120000"}},
+			{Channel: MatchChannelAudio, VideoSegment: &Segment{Start: "60000", Duration: "132000"}, ReferenceSegment: &Segment{Start: "60000", Duration: "132000"}},
+			{Channel: MatchChannelVideo, VideoSegment: &Segment{Start: "300000", Duration: "30000"}},
+		},
+	}
+	c, err := m.ReferenceCoverage(210 * time.Second)
+	require.NoError(t, err)
+	require.Equal(t, 192*time.Second, c.Matched)
+	require.Equal(t, "3:12 of 3:30 (91.4%)", c.String())
+
+	is, err := m.VideoIntervals(MatchChannelAudio)
+	require.NoError(t, err)
+	require.Len(t, is, 1)
+	require.Equal(t, "0:00-3:12", is[0].String())
+	require.Equal(t, "##--", RenderIntervals(is, 384*time.Second, 4))
+}
@@ -217,6 +217,11 @@ func (p *ListMusicTracksParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListMusicTracksParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 func musicTracksUrl(parent string) string {
 	return YoutubePartnerV1 + "/music/" + parent + "/tracks"
 }
@@ -296,6 +301,11 @@ func (p *ListMusicReleasesParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListMusicReleasesParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListMusicReleases retrieves a list of music releases owned by the content
 // owner. Results can be filtered by artist name, title, UPC, or change
 // request status.
@@ -352,6 +362,11 @@ func (p *ListMusicChangeRequestsParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListMusicChangeRequestsParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListMusicChangeRequests retrieves a list of music change requests for the
 // content owner. Results can be filtered by parent resource (release or track).
 //
@@ -0,0 +1,49 @@
+package youtube
+
+import (
+	"net/http"
+)
+
+// OAuthCallback is the authorization response Google sends to the redirect uri.
+type OAuthCallback struct {
+	// Code is the authorization code to exchange through ExchangeAuthToken.
+	Code string
+
+	// State is the state passed in OAuthOptions. It must be verified by the caller, e.g. through StateCodec.Verify.
+	State string
+
+	// Scopes are the scopes the user granted, which may differ from the requested scopes.
+	Scopes []Scope
+
+	// HostedDomain is the Google Workspace domain of the user, if any.
+	HostedDomain string
+
+	// ErrorDescription is the human readable description accompanying an error, if provided.
+	ErrorDescription string
+}
+
+// ParseOAuthCallback parses the authorization response from the request to the redirect uri. Both query strings and
+// form_post bodies are supported. If Google reports an error, it is returned as an OAuthError (e.g., ErrAccessDenied,
+// ErrAdminPolicyEnforced or ErrOrgInternal); the returned callback still carries the State so that it can be verified.
+// If the request contains neither a code nor an error, ErrMissingCode is returned.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/web-server#handlingresponse
+func ParseOAuthCallback(r *http.Request) (*OAuthCallback, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	c := &OAuthCallback{
+		Code:             r.Form.Get("code"),
+		State:            r.Form.Get("state"),
+		Scopes:           ParseScopes(r.Form.Get("scope")),
+		HostedDomain:     r.Form.Get("hd"),
+		ErrorDescription: r.Form.Get("error_description"),
+	}
+	if e := r.Form.Get("error"); e != "" {
+		return c, OAuthError(e)
+	}
+	if c.Code == "" {
+		return c, ErrMissingCode
+	}
+	return c, nil
+}
@@ -0,0 +1,71 @@
+package youtube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOAuthCallback(t *testing.T) {
+	scope := url.QueryEscape(string(ScopeReadOnly) + " " + string(ScopeUserEmail))
+	r := httptest.NewRequest(http.MethodGet, "/callback?code=abc&state=xyz&hd=example.com&scope="+scope, nil)
+	c, err := ParseOAuthCallback(r)
+	require.NoError(t, err)
+	require.Equal(t, &OAuthCallback{
+		Code:         "abc",
+		State:        "xyz",
+		Scopes:       []Scope{ScopeReadOnly, ScopeUserEmail},
+		HostedDomain: "example.com",
+	}, c)
+}
+
+func TestParseOAuthCallback_FormPost(t *testing.T) {
+	body := url.Values{"code": {"abc"}, "state": {"xyz"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c, err := ParseOAuthCallback(r)
+	require.NoError(t, err)
+	require.Equal(t, "abc", c.Code)
+	require.Equal(t, "xyz", c.State)
+}
+
+func TestParseOAuthCallback_Errors(t *testing.T) {
+	for _, want := range []OAuthError{
+		ErrAccessDenied,
+		ErrAccountSelectionRequired,
+		ErrAdminPolicyEnforced,
+		ErrConsentRequired,
+		ErrDisallowedUserAgent,
+		ErrExpiredToken,
+		ErrInteractionRequired,
+		ErrInvalidClient,
+		ErrInvalidRequest,
+		ErrLoginRequired,
+		ErrOrgInternal,
+		ErrRedirectUriMismatch,
+		ErrServerError,
+		ErrTemporarilyUnavailable,
+		ErrUnauthorizedClient,
+		ErrUnsupportedResponseType,
+	} {
+		t.Run(string(want), func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/callback?error="+string(want)+"&error_description=denied&state=xyz", nil)
+			c, err := ParseOAuthCallback(r)
+			require.ErrorIs(t, err, want)
+			require.NotNil(t, c, "the callback is returned so that its state can be verified")
+			require.Equal(t, "xyz", c.State)
+			require.Equal(t, "denied", c.ErrorDescription)
+		})
+	}
+
+	t.Run("missing code", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/callback?state=xyz", nil)
+		c, err := ParseOAuthCallback(r)
+		require.ErrorIs(t, err, ErrMissingCode)
+		require.Equal(t, "xyz", c.State)
+	})
+}
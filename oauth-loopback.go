@@ -0,0 +1,215 @@
+package youtube
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// loopbackStateBytes is the amount of random data in the state generated for a loopback flow.
+	loopbackStateBytes = 24
+
+	// loopbackShutdownTimeout is how long the loopback listener is given to finish in-flight responses.
+	loopbackShutdownTimeout = 5 * time.Second
+)
+
+var (
+	ErrMissingOnAuthUrl = errors.New("missing OnAuthUrl")
+)
+
+// LoopbackOptions configures AuthorizeLoopback.
+type LoopbackOptions struct {
+	// OAuthOptions describe the authorization request. RedirectUri and State are overwritten by AuthorizeLoopback. If
+	// StateCodec is set, the state is signed with it and verified at the callback.
+	OAuthOptions
+
+	// ClientSecret is the secret of the installed application's client.
+	ClientSecret string
+
+	// CodeVerifier is the PKCE code verifier sent when exchanging the code. If CodeChallenge is set, it must be the
+	// verifier the challenge was derived from. If both are empty, a verifier is generated and its S256 challenge is
+	// sent.
+	CodeVerifier string
+
+	// Timeout is the timeout for exchanging the authorization code.
+	Timeout time.Duration
+
+	// OnAuthUrl is called with the authorization URL once the listener is ready. It would typically open a browser or
+	// print the URL for the user. If it returns an error, the flow is aborted.
+	OnAuthUrl func(u *url.URL) error
+}
+
+// pkce fills in the code verifier and challenge, generating a verifier if neither was provided.
+func (o *LoopbackOptions) pkce() error {
+	switch {
+	case o.CodeChallenge != "" && o.CodeVerifier == "":
+		return ErrMissingCodeVerifier
+	case o.CodeChallenge != "":
+		return nil
+	case o.CodeVerifier == "":
+		v, err := GenerateCodeVerifier()
+		if err != nil {
+			return err
+		}
+		o.CodeVerifier = v
+	}
+	if !isValidPKCEValue(o.CodeVerifier) {
+		return ErrInvalidCodeVerifier
+	}
+	if o.CodeChallengeMethod == CodeChallengeMethodPlain {
+		o.CodeChallenge = o.CodeVerifier
+	} else {
+		o.CodeChallenge = CodeChallengeS256(o.CodeVerifier)
+	}
+	return nil
+}
+
+// state returns the state to send and the function verifying the state received at the callback.
+func (o *LoopbackOptions) state() (string, func(string) error, error) {
+	var state string
+	var err error
+	if o.StateCodec != nil {
+		state, err = o.StateCodec.Encode(o.StateData)
+	} else {
+		state, err = randomString(loopbackStateBytes)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	codec := o.StateCodec
+	verify := func(s string) error {
+		if subtle.ConstantTimeCompare([]byte(s), []byte(state)) != 1 {
+			return ErrInvalidState
+		}
+		if codec != nil {
+			_, err := codec.Verify(s)
+			return err
+		}
+		return nil
+	}
+	return state, verify, nil
+}
+
+type loopbackResult struct {
+	code string
+	err  error
+}
+
+// AuthorizeLoopback runs the installed-app OAuth flow through a temporary HTTP listener on 127.0.0.1. It generates a
+// state (signed with StateCodec, if set), builds the authorization URL with GenerateOAuthUrl and waits for Google to
+// redirect back to the listener. Errors reported by Google are returned as an OAuthError (e.g., ErrAccessDenied). The
+// code is then exchanged through ExchangeAuthTokenWithVerifier, as the flow always uses PKCE (see
+// LoopbackOptions.CodeVerifier). If the context is cancelled before the callback arrives, the context's error is
+// returned. The listener is shut down before returning.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/native-app#redirect-uri_loopback
+func AuthorizeLoopback(ctx context.Context, opts LoopbackOptions) (*Token, error) {
+	if opts.OnAuthUrl == nil {
+		return nil, ErrMissingOnAuthUrl
+	}
+	// Fragments are never sent to the server, so the listener would not receive the response.
+	if opts.ResponseMode == ResponseModeFragment {
+		return nil, ErrInvalidResponseMode
+	}
+	if err := opts.pkce(); err != nil {
+		return nil, err
+	}
+	state, verify, err := opts.state()
+	if err != nil {
+		return nil, err
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	redirect := "http://" + ln.Addr().String() + "/"
+
+	// The state is already signed, so GenerateOAuthUrl must not encode another one.
+	oauth := opts.OAuthOptions
+	oauth.RedirectUri = redirect
+	oauth.State = state
+	oauth.StateCodec = nil
+	u, err := GenerateOAuthUrl(oauth)
+	if err != nil {
+		ln.Close()
+		return nil, err
+	}
+
+	results := make(chan loopbackResult, 1)
+	srv := &http.Server{
+		Handler: loopbackHandler(verify, results),
+	}
+	go srv.Serve(ln)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), loopbackShutdownTimeout)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := opts.OnAuthUrl(u); err != nil {
+		return nil, err
+	}
+
+	var res loopbackResult
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-results:
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+	return ExchangeAuthTokenWithVerifier(opts.ClientId, opts.ClientSecret, res.code, opts.CodeVerifier, redirect, opts.Timeout)
+}
+
+// loopbackHandler handles the OAuth redirect, sending the first result on the provided channel. The state of the
+// callback is checked with verify. Requests without a code or error (e.g., a browser requesting a favicon) are ignored.
+func loopbackHandler(verify func(state string) error, results chan<- loopbackResult) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := ParseOAuthCallback(r)
+		if errors.Is(err, ErrMissingCode) {
+			http.NotFound(w, r)
+			return
+		}
+
+		var res loopbackResult
+		if c == nil {
+			res.err = err
+		} else if verr := verify(c.State); verr != nil {
+			res.err = verr
+		} else if err != nil {
+			res.err = err
+		} else {
+			res.code = c.Code
+		}
+
+		if res.err != nil {
+			http.Error(w, "Authorization failed: "+res.err.Error()+". You may close this window.", http.StatusBadRequest)
+		} else {
+			w.Write([]byte("Authorization complete. You may close this window."))
+		}
+
+		select {
+		case results <- res:
+		default:
+		}
+	})
+}
+
+// randomString returns n random bytes encoded as unpadded URL-safe base64.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,115 @@
+package youtube
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoopbackHandler(t *testing.T) {
+	verify := func(s string) error {
+		if s != "state" {
+			return ErrInvalidState
+		}
+		return nil
+	}
+	serve := func(target string) (*httptest.ResponseRecorder, *loopbackResult) {
+		results := make(chan loopbackResult, 1)
+		w := httptest.NewRecorder()
+		loopbackHandler(verify, results).ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		select {
+		case res := <-results:
+			return w, &res
+		default:
+			return w, nil
+		}
+	}
+
+	w, res := serve("/favicon.ico")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Nil(t, res, "requests without a code or error are ignored")
+
+	w, res = serve("/?code=abc&state=other")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, res)
+	require.ErrorIs(t, res.err, ErrInvalidState)
+
+	w, res = serve("/?error=access_denied&state=state")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, res)
+	require.ErrorIs(t, res.err, ErrAccessDenied)
+
+	// A forged error is rejected on its state before the error is reported.
+	_, res = serve("/?error=access_denied&state=other")
+	require.ErrorIs(t, res.err, ErrInvalidState)
+
+	w, res = serve("/?code=abc&state=state")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, res)
+	require.NoError(t, res.err)
+	require.Equal(t, "abc", res.code)
+}
+
+func TestLoopbackOptionsPkce(t *testing.T) {
+	var o LoopbackOptions
+	require.NoError(t, o.pkce())
+	require.True(t, isValidPKCEValue(o.CodeVerifier))
+	require.Equal(t, CodeChallengeS256(o.CodeVerifier), o.CodeChallenge)
+
+	verifier, err := GenerateCodeVerifier()
+	require.NoError(t, err)
+	o = LoopbackOptions{CodeVerifier: verifier}
+	o.CodeChallengeMethod = CodeChallengeMethodPlain
+	require.NoError(t, o.pkce())
+	require.Equal(t, verifier, o.CodeChallenge)
+
+	o = LoopbackOptions{}
+	o.CodeChallenge = CodeChallengeS256(verifier)
+	require.ErrorIs(t, o.pkce(), ErrMissingCodeVerifier)
+}
+
+func TestAuthorizeLoopback(t *testing.T) {
+	var got url.Values
+	withTokenServer(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		writeJson(w, http.StatusOK, Token{AccessToken: "access", RefreshToken: "refresh", ExpiresInSecs: 3600})
+	})
+
+	codec := &StateCodec{Key: []byte("secret"), Nonces: &MemoryNonceStore{}}
+	var challenge, state string
+	opts := LoopbackOptions{
+		OAuthOptions: OAuthOptions{
+			ClientId:   "client",
+			Scopes:     []Scope{ScopeReadOnly},
+			StateCodec: codec,
+			StateData:  "data",
+		},
+		Timeout: time.Second,
+		OnAuthUrl: func(u *url.URL) error {
+			q := u.Query()
+			challenge, state = q.Get("code_challenge"), q.Get("state")
+			res, err := http.Get(q.Get("redirect_uri") + "?code=abc&state=" + url.QueryEscape(state))
+			if err != nil {
+				return err
+			}
+			return res.Body.Close()
+		},
+	}
+
+	tok, err := AuthorizeLoopback(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, "access", tok.AccessToken)
+	require.Equal(t, "authorization_code", got.Get("grant_type"))
+	require.Equal(t, "abc", got.Get("code"))
+	require.Equal(t, challenge, CodeChallengeS256(got.Get("code_verifier")))
+	require.NotContains(t, got, "client_secret")
+
+	// The state was signed by the codec and its nonce was used when the callback was verified.
+	_, err = codec.Verify(state)
+	require.ErrorIs(t, err, ErrReplayedState)
+}
@@ -0,0 +1,45 @@
+package youtube
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+const (
+	// codeVerifierBytes is the amount of random data in a generated code verifier. 32 bytes encode to 43 characters,
+	// the minimum length allowed by RFC 7636.
+	codeVerifierBytes = 32
+)
+
+// GenerateCodeVerifier generates a random PKCE code verifier. Keep the verifier for the duration of the OAuth flow and
+// pass its challenge (see CodeChallengeS256) in OAuthOptions.
+//
+// @see https://datatracker.ietf.org/doc/html/rfc7636#section-4.1
+func GenerateCodeVerifier() (string, error) {
+	return randomString(codeVerifierBytes)
+}
+
+// CodeChallengeS256 derives the S256 code challenge for the provided code verifier.
+//
+// @see https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// isValidPKCEValue checks that a code verifier or plain code challenge uses only unreserved characters and is between
+// 43 and 128 characters long.
+func isValidPKCEValue(s string) bool {
+	if len(s) < 43 || len(s) > 128 {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case c == '-', c == '.', c == '_', c == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
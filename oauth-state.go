@@ -0,0 +1,155 @@
+package youtube
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultStateTTL is the lifetime of a signed state when StateCodec.TTL is not set.
+	DefaultStateTTL = 10 * time.Minute
+
+	// stateNonceBytes is the amount of random data in the nonce of a signed state.
+	stateNonceBytes = 16
+)
+
+var (
+	ErrConflictingState = errors.New("state and state codec are both set")
+	ErrExpiredState     = errors.New("expired state")
+	ErrMissingStateKey  = errors.New("missing state key")
+	ErrReplayedState    = errors.New("replayed state")
+)
+
+// NonceStore records the nonces of states which have been verified so that a state cannot be used twice.
+type NonceStore interface {
+	// Use marks the nonce as used until the provided expiry. It returns false if the nonce had already been used.
+	Use(nonce string, expiry time.Time) (bool, error)
+}
+
+// MemoryNonceStore is an in-memory NonceStore. Expired nonces are pruned as new ones are used. The zero value is ready
+// to use. It is only suitable when callbacks are handled by a single process.
+type MemoryNonceStore struct {
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+func (s *MemoryNonceStore) Use(nonce string, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.used == nil {
+		s.used = make(map[string]time.Time)
+	}
+	for n, exp := range s.used {
+		if now.After(exp) {
+			delete(s.used, n)
+		}
+	}
+	if _, ok := s.used[nonce]; ok {
+		return false, nil
+	}
+	s.used[nonce] = expiry
+	return true, nil
+}
+
+// StatePayload is the content of a signed state.
+type StatePayload struct {
+	// Nonce is random data which makes every state unique.
+	Nonce string `json:"n"`
+	// Expiry is the time, in seconds since the unix epoch, after which the state is rejected.
+	Expiry int64 `json:"e"`
+	// Data is arbitrary application data, such as a return-to URL or a user id.
+	Data string `json:"d,omitempty"`
+}
+
+// StateCodec produces and verifies HMAC-signed, expiring OAuth state parameters for CSRF protection. Set it on
+// OAuthOptions.StateCodec to have GenerateOAuthUrl embed a signed state, then call Verify with the state received at
+// the callback.
+type StateCodec struct {
+	// Key is the HMAC-SHA256 key used to sign states. It must be kept secret.
+	Key []byte
+
+	// TTL is how long a state remains valid. Defaults to DefaultStateTTL.
+	TTL time.Duration
+
+	// Nonces, if provided, is used to reject states which have already been verified once.
+	Nonces NonceStore
+}
+
+// Encode creates a signed state embedding the provided data.
+func (c *StateCodec) Encode(data string) (string, error) {
+	if len(c.Key) == 0 {
+		return "", ErrMissingStateKey
+	}
+	nonce, err := randomString(stateNonceBytes)
+	if err != nil {
+		return "", err
+	}
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultStateTTL
+	}
+	b, err := json.Marshal(StatePayload{
+		Nonce:  nonce,
+		Expiry: time.Now().Add(ttl).Unix(),
+		Data:   data,
+	})
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Verify checks the signature and expiry of a state and, if a NonceStore is configured, that it has not been used
+// before. It returns the payload on success. ErrInvalidState is returned for tampered or malformed states,
+// ErrExpiredState for expired states and ErrReplayedState for states which were already verified.
+func (c *StateCodec) Verify(state string) (*StatePayload, error) {
+	if len(c.Key) == 0 {
+		return nil, ErrMissingStateKey
+	}
+	payload, sig, ok := strings.Cut(state, ".")
+	if !ok {
+		return nil, ErrInvalidState
+	}
+	rawSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(rawSig, c.sign(payload)) {
+		return nil, ErrInvalidState
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	var p StatePayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, ErrInvalidState
+	}
+
+	expiry := time.Unix(p.Expiry, 0)
+	if time.Now().After(expiry) {
+		return nil, ErrExpiredState
+	}
+	if c.Nonces != nil {
+		fresh, err := c.Nonces.Use(p.Nonce, expiry)
+		if err != nil {
+			return nil, err
+		}
+		if !fresh {
+			return nil, ErrReplayedState
+		}
+	}
+	return &p, nil
+}
+
+func (c *StateCodec) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, c.Key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,75 @@
+package youtube
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCodec(t *testing.T) {
+	c := &StateCodec{Key: []byte("secret"), Nonces: &MemoryNonceStore{}}
+
+	state, err := c.Encode("/return-to")
+	require.NoError(t, err)
+
+	p, err := c.Verify(state)
+	require.NoError(t, err)
+	require.Equal(t, "/return-to", p.Data)
+
+	_, err = c.Verify(state)
+	require.ErrorIs(t, err, ErrReplayedState)
+
+	other, err := (&StateCodec{Key: []byte("other")}).Encode("/return-to")
+	require.NoError(t, err)
+	_, err = c.Verify(other)
+	require.ErrorIs(t, err, ErrInvalidState, "state signed with another key")
+
+	state, err = c.Encode("/return-to")
+	require.NoError(t, err)
+	payload, sig, _ := strings.Cut(state, ".")
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	require.NoError(t, err)
+	tampered := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(b), "/return-to", "/evil", 1)))
+	_, err = c.Verify(tampered + "." + sig)
+	require.ErrorIs(t, err, ErrInvalidState, "tampered payload")
+
+	for _, s := range []string{"", "nodot", "!!.!!"} {
+		_, err = c.Verify(s)
+		require.ErrorIs(t, err, ErrInvalidState, s)
+	}
+
+	b, err = json.Marshal(StatePayload{Nonce: "n", Expiry: time.Now().Add(-time.Second).Unix()})
+	require.NoError(t, err)
+	expired := base64.RawURLEncoding.EncodeToString(b)
+	_, err = c.Verify(expired + "." + base64.RawURLEncoding.EncodeToString(c.sign(expired)))
+	require.ErrorIs(t, err, ErrExpiredState)
+
+	_, err = (&StateCodec{}).Encode("")
+	require.ErrorIs(t, err, ErrMissingStateKey)
+}
+
+func TestGenerateOAuthUrlStateCodec(t *testing.T) {
+	c := &StateCodec{Key: []byte("secret")}
+	opts := OAuthOptions{
+		ClientId:    "client",
+		RedirectUri: "http://127.0.0.1:8080/callback",
+		Scopes:      []Scope{ScopeReadOnly},
+		StateCodec:  c,
+		StateData:   "/return-to",
+	}
+	u, err := GenerateOAuthUrl(opts)
+	require.NoError(t, err)
+	p, err := c.Verify(u.Query().Get("state"))
+	require.NoError(t, err)
+	require.Equal(t, "/return-to", p.Data)
+
+	// Setting both is a configuration error, which must not be mistaken for a tampered state.
+	opts.State = "state"
+	_, err = GenerateOAuthUrl(opts)
+	require.ErrorIs(t, err, ErrConflictingState)
+	require.NotErrorIs(t, err, ErrInvalidState)
+}
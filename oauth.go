@@ -2,6 +2,7 @@ package youtube
 
 import (
 	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -12,17 +13,21 @@ const (
 type Scope string
 type AccessType string
 type Prompt string
+type CodeChallengeMethod string
+type ResponseMode string
 
 const (
-	ScopeAccount            Scope = "https://www.googleapis.com/auth/youtube"
-	ScopeChannelMemberships Scope = "https://www.googleapis.com/auth/youtube.channel-memberships.creator"
-	ScopeForceSSL           Scope = "https://www.googleapis.com/auth/youtube.force-ssl"
-	ScopeReadOnly           Scope = "https://www.googleapis.com/auth/youtube.readonly"
-	ScopeUpload             Scope = "https://www.googleapis.com/auth/youtube.upload"
-	ScopePartner            Scope = "https://www.googleapis.com/auth/youtubepartner"
-	ScopeAudit              Scope = "https://www.googleapis.com/auth/youtubepartner-channel-audit"
-	ScopeUserEmail          Scope = "https://www.googleapis.com/auth/userinfo.email"
-	ScopeUserProfile        Scope = "https://www.googleapis.com/auth/userinfo.profile"
+	ScopeAccount                     Scope = "https://www.googleapis.com/auth/youtube"
+	ScopeChannelMemberships          Scope = "https://www.googleapis.com/auth/youtube.channel-memberships.creator"
+	ScopeForceSSL                    Scope = "https://www.googleapis.com/auth/youtube.force-ssl"
+	ScopeReadOnly                    Scope = "https://www.googleapis.com/auth/youtube.readonly"
+	ScopeUpload                      Scope = "https://www.googleapis.com/auth/youtube.upload"
+	ScopePartner                     Scope = "https://www.googleapis.com/auth/youtubepartner"
+	ScopeAudit                       Scope = "https://www.googleapis.com/auth/youtubepartner-channel-audit"
+	ScopePartnerContentOwnerReadOnly Scope = "https://www.googleapis.com/auth/youtubepartner-content-owner-readonly"
+	ScopeUserEmail                   Scope = "https://www.googleapis.com/auth/userinfo.email"
+	ScopeUserProfile                 Scope = "https://www.googleapis.com/auth/userinfo.profile"
+	ScopeOpenId                      Scope = "openid"
 
 	AccessTypeOnline  AccessType = "online"
 	AccessTypeOffline AccessType = "offline"
@@ -30,8 +35,18 @@ const (
 	PromptNone    Prompt = "none"
 	PromptConsent Prompt = "consent"
 	PromptSelect  Prompt = "select_account"
+
+	CodeChallengeMethodS256  CodeChallengeMethod = "S256"
+	CodeChallengeMethodPlain CodeChallengeMethod = "plain"
+
+	ResponseModeQuery    ResponseMode = "query"
+	ResponseModeFragment ResponseMode = "fragment"
+	ResponseModeFormPost ResponseMode = "form_post"
 )
 
+// OAuthOptions describe an authorization request to Google's OAuth 2.0 server.
+//
+// @see https://developers.google.com/identity/protocols/oauth2/web-server#creatingclient
 type OAuthOptions struct {
 	ClientId             string
 	RedirectUri          string
@@ -39,8 +54,56 @@ type OAuthOptions struct {
 	AccessType           AccessType
 	State                string
 	IncludeGrantedScopes bool
-	LoginHint            bool
 	Prompts              []Prompt
+
+	// LoginHint is the email address or sub identifier of the user, used to pre-select the account or pre-fill the
+	// email box on the sign-in form.
+	LoginHint string
+
+	// HostedDomain limits the accounts offered on the sign-in form to the provided Google Workspace domain. Use "*"
+	// to only offer Workspace accounts. This is only a hint; verify the hd claim of the ID token afterwards.
+	HostedDomain string
+
+	// EnableGranularConsent, if set, controls whether users may grant a subset of the requested scopes. Note that
+	// Google is rolling out granular consent to all applications and will eventually ignore false.
+	EnableGranularConsent *bool
+
+	// ResponseMode is how the authorization response is returned to the redirect uri. Defaults to a query string.
+	ResponseMode ResponseMode
+
+	// Nonce is returned in the ID token, when the openid scope is requested, to mitigate replay attacks.
+	Nonce string
+
+	// CodeChallenge is the PKCE code challenge derived from a code verifier. Use GenerateCodeVerifier and
+	// CodeChallengeS256 to produce one. The verifier must then be sent through ExchangeAuthTokenWithVerifier.
+	CodeChallenge string
+
+	// CodeChallengeMethod is how CodeChallenge was derived from the verifier. Defaults to CodeChallengeMethodS256.
+	CodeChallengeMethod CodeChallengeMethod
+
+	// StateCodec, if provided, makes GenerateOAuthUrl embed a signed state carrying StateData. State must then be
+	// left empty, otherwise ErrConflictingState is returned.
+	StateCodec *StateCodec
+	StateData  string
+}
+
+func (m ResponseMode) IsValid() bool {
+	if m == "" {
+		return true
+	}
+	switch m {
+	case ResponseModeQuery, ResponseModeFragment, ResponseModeFormPost:
+		return true
+	}
+	return false
+}
+
+func (m CodeChallengeMethod) IsValid() bool {
+	switch m {
+	case CodeChallengeMethodS256, CodeChallengeMethodPlain:
+		return true
+	}
+	return false
 }
 
 func (p Prompt) IsValid() bool {
@@ -64,12 +127,38 @@ func (t AccessType) IsValid() bool {
 
 func (s Scope) IsValid() bool {
 	switch s {
-	case ScopeAudit, ScopeAccount, ScopeChannelMemberships, ScopeForceSSL, ScopeReadOnly, ScopeUpload, ScopePartner, ScopeUserEmail, ScopeUserProfile:
+	case ScopeAudit, ScopeAccount, ScopeChannelMemberships, ScopeForceSSL, ScopeReadOnly, ScopeUpload, ScopePartner, ScopePartnerContentOwnerReadOnly, ScopeUserEmail, ScopeUserProfile, ScopeOpenId:
 		return true
 	}
 	return false
 }
 
+// ParseScopes splits a space separated scope string, such as the one returned in Token.Scope, into Scopes.
+func ParseScopes(s string) []Scope {
+	fields := strings.Fields(s)
+	scopes := make([]Scope, 0, len(fields))
+	for _, f := range fields {
+		scopes = append(scopes, Scope(f))
+	}
+	return scopes
+}
+
+// MissingScopes returns the scopes in required which are not present in granted. The result is empty if every
+// required scope was granted.
+func MissingScopes(granted, required []Scope) []Scope {
+	has := make(map[Scope]bool, len(granted))
+	for _, s := range granted {
+		has[s] = true
+	}
+	var missing []Scope
+	for _, s := range required {
+		if !has[s] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
 func (o OAuthOptions) Validate() error {
 	if o.ClientId == "" {
 		return ErrMissingClientId
@@ -92,6 +181,28 @@ func (o OAuthOptions) Validate() error {
 		if !p.IsValid() {
 			return ErrInvalidPrompt
 		}
+		// none cannot be combined with other prompts.
+		if p == PromptNone && len(o.Prompts) > 1 {
+			return ErrInvalidPrompt
+		}
+	}
+	if !o.ResponseMode.IsValid() {
+		return ErrInvalidResponseMode
+	}
+	if strings.ContainsAny(o.HostedDomain, " /@") {
+		return ErrInvalidHostedDomain
+	}
+	if o.StateCodec != nil && o.State != "" {
+		return ErrConflictingState
+	}
+	if o.CodeChallengeMethod != "" && !o.CodeChallengeMethod.IsValid() {
+		return ErrInvalidCodeChallengeMethod
+	}
+	if o.CodeChallengeMethod != "" && o.CodeChallenge == "" {
+		return ErrMissingCodeChallenge
+	}
+	if o.CodeChallenge != "" && !isValidPKCEValue(o.CodeChallenge) {
+		return ErrInvalidCodeChallenge
 	}
 	return nil
 }
@@ -134,12 +245,32 @@ func (o OAuthOptions) Values() url.Values {
 	if o.IncludeGrantedScopes {
 		v.Add("include_granted_scopes", "true")
 	}
-	if o.LoginHint {
-		v.Add("login_hint", "true")
+	if o.LoginHint != "" {
+		v.Add("login_hint", o.LoginHint)
+	}
+	if o.HostedDomain != "" {
+		v.Add("hd", o.HostedDomain)
+	}
+	if o.EnableGranularConsent != nil {
+		v.Add("enable_granular_consent", strconv.FormatBool(*o.EnableGranularConsent))
+	}
+	if o.ResponseMode != "" {
+		v.Add("response_mode", string(o.ResponseMode))
+	}
+	if o.Nonce != "" {
+		v.Add("nonce", o.Nonce)
 	}
 	if len(o.Prompts) > 0 {
 		v.Add("prompt", strings.Join(o.convertPrompts(), " "))
 	}
+	if o.CodeChallenge != "" {
+		v.Add("code_challenge", o.CodeChallenge)
+		method := o.CodeChallengeMethod
+		if method == "" {
+			method = CodeChallengeMethodS256
+		}
+		v.Add("code_challenge_method", string(method))
+	}
 	return v
 }
 
@@ -147,6 +278,13 @@ func GenerateOAuthUrl(options OAuthOptions) (*url.URL, error) {
 	if err := options.Validate(); err != nil {
 		return nil, err
 	}
+	if options.StateCodec != nil {
+		state, err := options.StateCodec.Encode(options.StateData)
+		if err != nil {
+			return nil, err
+		}
+		options.State = state
+	}
 	vals := options.Values()
 	return url.Parse(OAuthUrl + "?" + vals.Encode())
 }
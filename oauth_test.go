@@ -0,0 +1,57 @@
+package youtube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOAuthUrlCodeChallenge(t *testing.T) {
+	verifier, err := GenerateCodeVerifier()
+	require.NoError(t, err)
+	challenge := CodeChallengeS256(verifier)
+
+	opts := OAuthOptions{
+		ClientId:      "client",
+		RedirectUri:   "http://127.0.0.1:8080/callback",
+		Scopes:        []Scope{ScopeReadOnly},
+		CodeChallenge: challenge,
+	}
+	u, err := GenerateOAuthUrl(opts)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, challenge, q.Get("code_challenge"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"), "method should default to S256")
+
+	opts.CodeChallengeMethod = CodeChallengeMethodPlain
+	opts.CodeChallenge = verifier
+	u, err = GenerateOAuthUrl(opts)
+	require.NoError(t, err)
+	require.Equal(t, "plain", u.Query().Get("code_challenge_method"))
+
+	opts.CodeChallenge = ""
+	_, err = GenerateOAuthUrl(opts)
+	require.ErrorIs(t, err, ErrMissingCodeChallenge)
+}
+
+func TestMissingScopes(t *testing.T) {
+	granted := ParseScopes(string(ScopeReadOnly) + "  " + string(ScopePartner) + "\n")
+	require.Equal(t, []Scope{ScopeReadOnly, ScopePartner}, granted)
+
+	require.Empty(t, MissingScopes(granted, nil))
+	require.Empty(t, MissingScopes(granted, []Scope{ScopePartner}))
+	require.Equal(t, []Scope{ScopeUpload, ScopeAccount},
+		MissingScopes(granted, []Scope{ScopeUpload, ScopeReadOnly, ScopeAccount}))
+	require.Equal(t, []Scope{ScopeReadOnly}, MissingScopes(nil, []Scope{ScopeReadOnly}))
+
+	tok := &Token{Scope: string(ScopeReadOnly)}
+	require.Equal(t, []Scope{ScopeReadOnly}, tok.Scopes())
+
+	info := &TokenInfo{Scope: string(ScopePartner), Exp: "1700000000", EmailVerified: "true"}
+	require.Equal(t, []Scope{ScopeReadOnly}, info.MissingScopes([]Scope{ScopePartner, ScopeReadOnly}))
+	require.Equal(t, int64(1700000000), info.Expiry().Unix())
+	require.True(t, info.IsEmailVerified())
+
+	info.Exp = "soon"
+	require.True(t, info.Expiry().IsZero())
+}
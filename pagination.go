@@ -0,0 +1,357 @@
+package youtube
+
+import (
+	"iter"
+)
+
+// PageOptions limit how far a pagination iterator reads. Zero values mean no limit.
+type PageOptions struct {
+	// MaxItems stops the iteration once this many items have been read.
+	MaxItems int
+
+	// MaxPages stops the iteration once this many pages have been read.
+	MaxPages int
+}
+
+// Page is a single page of results returned by a paginated endpoint.
+type Page[T any] struct {
+	// Items on the page.
+	Items []*T
+
+	// PageInfo is the paging information reported by the endpoint. It is nil for endpoints which do not report it.
+	PageInfo *PageInfo
+
+	// PageToken is the token used to request this page. It is empty for the first page.
+	PageToken string
+
+	// NextPageToken is the token of the following page. It is empty for the last page.
+	NextPageToken string
+}
+
+// paginate iterates over pages, starting from the provided page token and following NextPageToken until the last
+// page or a limit in the options is reached. An error ends the iteration after it is yielded.
+func paginate[T any](fetch func(pageToken string) (*Page[T], error), pageToken string, o PageOptions) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		var pages, items int
+		for {
+			page, err := fetch(pageToken)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			page.PageToken = pageToken
+			if !yield(page, nil) {
+				return
+			}
+			pages++
+			items += len(page.Items)
+
+			switch {
+			case page.NextPageToken == "", page.NextPageToken == pageToken:
+				return
+			case o.MaxPages > 0 && pages >= o.MaxPages:
+				return
+			case o.MaxItems > 0 && items >= o.MaxItems:
+				return
+			}
+			pageToken = page.NextPageToken
+		}
+	}
+}
+
+// pageItems flattens pages into their items, stopping exactly at MaxItems.
+func pageItems[T any](pages iter.Seq2[*Page[T], error], o PageOptions) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		var count int
+		for page, err := range pages {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range page.Items {
+				if o.MaxItems > 0 && count >= o.MaxItems {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				count++
+			}
+		}
+	}
+}
+
+// SearchClaimsPages iterates over the pages of SearchClaims, following page tokens automatically. Iteration starts from
+// p.PageToken; p itself is not modified.
+func SearchClaimsPages(runner RequestRunner, p *SearchClaimsParams, o PageOptions) iter.Seq2[*Page[ClaimSnippet], error] {
+	return paginate(func(pageToken string) (*Page[ClaimSnippet], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := SearchClaims(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[ClaimSnippet]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// SearchClaimsIter iterates over every item returned by SearchClaims, following page tokens automatically.
+func SearchClaimsIter(runner RequestRunner, p *SearchClaimsParams, o PageOptions) iter.Seq2[*ClaimSnippet, error] {
+	return pageItems(SearchClaimsPages(runner, p, o), o)
+}
+
+// ListClaimsPages iterates over the pages of ListClaims, following page tokens automatically. Iteration starts from
+// p.PageToken; p itself is not modified.
+func ListClaimsPages(runner RequestRunner, p *ListClaimsParams, o PageOptions) iter.Seq2[*Page[Claim], error] {
+	return paginate(func(pageToken string) (*Page[Claim], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListClaims(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Claim]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListClaimsIter iterates over every item returned by ListClaims, following page tokens automatically.
+func ListClaimsIter(runner RequestRunner, p *ListClaimsParams, o PageOptions) iter.Seq2[*Claim, error] {
+	return pageItems(ListClaimsPages(runner, p, o), o)
+}
+
+// SearchAssetsPages iterates over the pages of SearchAssets, following page tokens automatically. Iteration starts from
+// p.PageToken; p itself is not modified.
+func SearchAssetsPages(runner RequestRunner, p *SearchAssetsParams, o PageOptions) iter.Seq2[*Page[AssetSnippet], error] {
+	return paginate(func(pageToken string) (*Page[AssetSnippet], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := SearchAssets(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[AssetSnippet]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// SearchAssetsIter iterates over every item returned by SearchAssets, following page tokens automatically.
+func SearchAssetsIter(runner RequestRunner, p *SearchAssetsParams, o PageOptions) iter.Seq2[*AssetSnippet, error] {
+	return pageItems(SearchAssetsPages(runner, p, o), o)
+}
+
+// ListReferencesPages iterates over the pages of ListReferences, following page tokens automatically. Iteration starts
+// from p.PageToken; p itself is not modified.
+func ListReferencesPages(runner RequestRunner, p *ListReferencesParams, o PageOptions) iter.Seq2[*Page[Reference], error] {
+	return paginate(func(pageToken string) (*Page[Reference], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListReferences(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Reference]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListReferencesIter iterates over every item returned by ListReferences, following page tokens automatically.
+func ListReferencesIter(runner RequestRunner, p *ListReferencesParams, o PageOptions) iter.Seq2[*Reference, error] {
+	return pageItems(ListReferencesPages(runner, p, o), o)
+}
+
+// ListReferenceConflictsPages iterates over the pages of ListReferenceConflicts, following page tokens automatically.
+// Iteration starts from p.PageToken; p itself is not modified.
+func ListReferenceConflictsPages(runner RequestRunner, p *ListReferenceConflictsParams, o PageOptions) iter.Seq2[*Page[ReferenceConflict], error] {
+	return paginate(func(pageToken string) (*Page[ReferenceConflict], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListReferenceConflicts(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[ReferenceConflict]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListReferenceConflictsIter iterates over every item returned by ListReferenceConflicts, following page tokens
+// automatically.
+func ListReferenceConflictsIter(runner RequestRunner, p *ListReferenceConflictsParams, o PageOptions) iter.Seq2[*ReferenceConflict, error] {
+	return pageItems(ListReferenceConflictsPages(runner, p, o), o)
+}
+
+// ListWhitelistsPages iterates over the pages of ListWhitelists, following page tokens automatically. Iteration starts
+// from p.PageToken; p itself is not modified.
+func ListWhitelistsPages(runner RequestRunner, p *ListWhitelistsParams, o PageOptions) iter.Seq2[*Page[Whitelist], error] {
+	return paginate(func(pageToken string) (*Page[Whitelist], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListWhitelists(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Whitelist]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListWhitelistsIter iterates over every item returned by ListWhitelists, following page tokens automatically.
+func ListWhitelistsIter(runner RequestRunner, p *ListWhitelistsParams, o PageOptions) iter.Seq2[*Whitelist, error] {
+	return pageItems(ListWhitelistsPages(runner, p, o), o)
+}
+
+// ListAssetSharesPages iterates over the pages of ListAssetShares, following page tokens automatically. Iteration
+// starts from p.PageToken; p itself is not modified.
+func ListAssetSharesPages(runner RequestRunner, p *ListAssetSharesParams, o PageOptions) iter.Seq2[*Page[AssetShare], error] {
+	return paginate(func(pageToken string) (*Page[AssetShare], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListAssetShares(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[AssetShare]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListAssetSharesIter iterates over every item returned by ListAssetShares, following page tokens automatically.
+func ListAssetSharesIter(runner RequestRunner, p *ListAssetSharesParams, o PageOptions) iter.Seq2[*AssetShare, error] {
+	return pageItems(ListAssetSharesPages(runner, p, o), o)
+}
+
+// ListMusicTracksPages iterates over the pages of ListMusicTracks, following page tokens automatically. Iteration
+// starts from p.PageToken; p itself is not modified.
+func ListMusicTracksPages(runner RequestRunner, p *ListMusicTracksParams, o PageOptions) iter.Seq2[*Page[MusicTrack], error] {
+	return paginate(func(pageToken string) (*Page[MusicTrack], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListMusicTracks(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[MusicTrack]{
+			Items:         res.Tracks,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListMusicTracksIter iterates over every item returned by ListMusicTracks, following page tokens automatically.
+func ListMusicTracksIter(runner RequestRunner, p *ListMusicTracksParams, o PageOptions) iter.Seq2[*MusicTrack, error] {
+	return pageItems(ListMusicTracksPages(runner, p, o), o)
+}
+
+// ListMusicReleasesPages iterates over the pages of ListMusicReleases, following page tokens automatically. Iteration
+// starts from p.PageToken; p itself is not modified.
+func ListMusicReleasesPages(runner RequestRunner, p *ListMusicReleasesParams, o PageOptions) iter.Seq2[*Page[MusicRelease], error] {
+	return paginate(func(pageToken string) (*Page[MusicRelease], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListMusicReleases(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[MusicRelease]{
+			Items:         res.Releases,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListMusicReleasesIter iterates over every item returned by ListMusicReleases, following page tokens automatically.
+func ListMusicReleasesIter(runner RequestRunner, p *ListMusicReleasesParams, o PageOptions) iter.Seq2[*MusicRelease, error] {
+	return pageItems(ListMusicReleasesPages(runner, p, o), o)
+}
+
+// ListMusicChangeRequestsPages iterates over the pages of ListMusicChangeRequests, following page tokens automatically.
+// Iteration starts from p.PageToken; p itself is not modified.
+func ListMusicChangeRequestsPages(runner RequestRunner, p *ListMusicChangeRequestsParams, o PageOptions) iter.Seq2[*Page[MusicChangeRequest], error] {
+	return paginate(func(pageToken string) (*Page[MusicChangeRequest], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListMusicChangeRequests(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[MusicChangeRequest]{
+			Items:         res.ChangeRequests,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListMusicChangeRequestsIter iterates over every item returned by ListMusicChangeRequests, following page tokens
+// automatically.
+func ListMusicChangeRequestsIter(runner RequestRunner, p *ListMusicChangeRequestsParams, o PageOptions) iter.Seq2[*MusicChangeRequest, error] {
+	return pageItems(ListMusicChangeRequestsPages(runner, p, o), o)
+}
+
+// ListVideosPages iterates over the pages of ListVideos, following page tokens automatically. Iteration starts from
+// p.PageToken; p itself is not modified.
+func ListVideosPages(runner RequestRunner, p *ListVideoParams, o PageOptions) iter.Seq2[*Page[Video], error] {
+	return paginate(func(pageToken string) (*Page[Video], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListVideos(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Video]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListVideosIter iterates over every item returned by ListVideos, following page tokens automatically.
+func ListVideosIter(runner RequestRunner, p *ListVideoParams, o PageOptions) iter.Seq2[*Video, error] {
+	return pageItems(ListVideosPages(runner, p, o), o)
+}
+
+// ListChannelsPages iterates over the pages of ListChannels, following page tokens automatically. Iteration starts from
+// p.PageToken; p itself is not modified.
+func ListChannelsPages(runner RequestRunner, p *ListChannelsOpts, o PageOptions) iter.Seq2[*Page[Channel], error] {
+	return paginate(func(pageToken string) (*Page[Channel], error) {
+		q := *p
+		q.PageToken = pageToken
+		res, err := ListChannels(runner, &q)
+		if err != nil {
+			return nil, err
+		}
+		return &Page[Channel]{
+			Items:         res.Items,
+			PageInfo:      res.PageInfo,
+			NextPageToken: res.NextPageToken,
+		}, nil
+	}, p.PageToken, o)
+}
+
+// ListChannelsIter iterates over every item returned by ListChannels, following page tokens automatically.
+func ListChannelsIter(runner RequestRunner, p *ListChannelsOpts, o PageOptions) iter.Seq2[*Channel, error] {
+	return pageItems(ListChannelsPages(runner, p, o), o)
+}
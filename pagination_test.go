@@ -0,0 +1,73 @@
+package youtube
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakePagedRunner serves claimSearch pages of pageSize claims until total claims have been returned.
+type fakePagedRunner struct {
+	total    int
+	pageSize int
+	requests int
+}
+
+func (r *fakePagedRunner) Run(req *Request) (*http.Response, error) {
+	r.requests++
+	start, _ := strconv.Atoi(req.Params.Get("pageToken"))
+
+	var out ClaimSearchResponse
+	for i := start; i < start+r.pageSize && i < r.total; i++ {
+		out.Items = append(out.Items, &ClaimSnippet{Id: strconv.Itoa(i)})
+	}
+	if start+r.pageSize < r.total {
+		out.NextPageToken = strconv.Itoa(start + r.pageSize)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(b)),
+	}, nil
+}
+
+func TestSearchClaimsIter(t *testing.T) {
+	runner := &fakePagedRunner{total: 7, pageSize: 3}
+	p := &SearchClaimsParams{AssetId: "A123"}
+
+	var ids []string
+	for c, err := range SearchClaimsIter(runner, p, PageOptions{}) {
+		require.NoError(t, err)
+		ids = append(ids, c.Id)
+	}
+	require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, ids)
+	require.Equal(t, 3, runner.requests)
+	require.Empty(t, p.PageToken, "params should not be modified")
+}
+
+func TestSearchClaimsIterLimits(t *testing.T) {
+	runner := &fakePagedRunner{total: 10, pageSize: 3}
+	var count int
+	for _, err := range SearchClaimsIter(runner, &SearchClaimsParams{AssetId: "A123"}, PageOptions{MaxItems: 4}) {
+		require.NoError(t, err)
+		count++
+	}
+	require.Equal(t, 4, count)
+	require.Equal(t, 2, runner.requests)
+
+	runner = &fakePagedRunner{total: 10, pageSize: 3}
+	var tokens []string
+	for page, err := range SearchClaimsPages(runner, &SearchClaimsParams{AssetId: "A123"}, PageOptions{MaxPages: 2}) {
+		require.NoError(t, err)
+		tokens = append(tokens, page.PageToken)
+	}
+	require.Equal(t, []string{"", "3"}, tokens)
+}
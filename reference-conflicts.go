@@ -127,6 +127,11 @@ func (p *ListReferenceConflictsParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListReferenceConflictsParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListReferenceConflicts retrieves a list of unresolved reference conflicts.
 // Conflicts are returned in order of when the conflicting reference was created,
 // starting with the most recently created.
@@ -212,6 +212,11 @@ func (p *ListReferencesParams) Values() url.Values {
 	return v
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListReferencesParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListReferences retrieves a list of references by ID or the list of references
 // for the specified asset.
 //
@@ -0,0 +1,207 @@
+package youtube
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownEndpoint = errors.New("unknown endpoint")
+)
+
+var (
+	// scopesPartner allows every Content ID API call.
+	scopesPartner = []Scope{ScopePartner}
+
+	// scopesContentOwnerRead allows reading content owner details.
+	scopesContentOwnerRead = []Scope{ScopePartner, ScopePartnerContentOwnerReadOnly}
+
+	// scopesDataRead allows reading public and owned resources through the Data API.
+	scopesDataRead = []Scope{ScopeReadOnly, ScopeAccount, ScopeForceSSL, ScopePartner}
+
+	// scopesMine allows reading resources of the authenticated user through the Data API.
+	scopesMine = []Scope{ScopeReadOnly, ScopeAccount, ScopeForceSSL}
+
+	// scopesUserInfo allows reading the profile of the authenticated user.
+	scopesUserInfo = []Scope{ScopeUserEmail, ScopeUserProfile}
+
+	// scopesCloudPlatform allows calling the IAM credentials API to impersonate service accounts.
+	scopesCloudPlatform = []Scope{ScopeCloudPlatform}
+)
+
+// EndpointScopes maps the name of each library function calling a YouTube endpoint to the scopes which allow it. Any
+// one of the listed scopes is sufficient. Helpers built on a single endpoint, such as the *Iter and *Pages iterators,
+// bulk lookups and sharded searches, are listed with the scopes of that endpoint. Generic helpers such as ResumableIter
+// and methods of types such as DisputeQueue or ClaimWatcher are not listed; check the functions they call instead.
+var EndpointScopes = map[string][]Scope{
+	// Data API
+	"BulkListChannels":  scopesDataRead,
+	"BulkListVideos":    scopesDataRead,
+	"ListChannels":      scopesDataRead,
+	"ListChannelsIter":  scopesDataRead,
+	"ListChannelsPages": scopesDataRead,
+	"ListVideos":        scopesDataRead,
+	"ListVideosIter":    scopesDataRead,
+	"ListVideosPages":   scopesDataRead,
+	"MyChannel":         scopesMine,
+	"GetUserInfo":       scopesUserInfo,
+
+	// IAM credentials API
+	"GenerateAccessToken": scopesCloudPlatform,
+	"SignJwt":             scopesCloudPlatform,
+
+	// Content ID API
+	"BulkListClaims":               scopesPartner,
+	"BulkListReferences":           scopesPartner,
+	"BulkSearchAssetsByIsrc":       scopesPartner,
+	"CreateMusicChangeRequest":     scopesPartner,
+	"DeleteAssetRelationship":      scopesPartner,
+	"DeleteCampaign":               scopesPartner,
+	"DeleteWhitelist":              scopesPartner,
+	"ExportClaimSnippets":          scopesPartner,
+	"ExportClaims":                 scopesPartner,
+	"GetAsset":                     scopesPartner,
+	"GetAssetMatchPolicy":          scopesPartner,
+	"GetCampaign":                  scopesPartner,
+	"GetClaim":                     scopesPartner,
+	"GetClaimHistory":              scopesPartner,
+	"GetContentOwner":              scopesContentOwnerRead,
+	"GetEnabledAds":                scopesPartner,
+	"GetOwnership":                 scopesPartner,
+	"GetPackage":                   scopesPartner,
+	"GetPolicy":                    scopesPartner,
+	"GetReference":                 scopesPartner,
+	"GetReferenceConflict":         scopesPartner,
+	"GetVideoAdvertisingOption":    scopesPartner,
+	"GetWhitelist":                 scopesPartner,
+	"InsertAsset":                  scopesPartner,
+	"InsertAssetLabel":             scopesPartner,
+	"InsertAssetRelationship":      scopesPartner,
+	"InsertCampaign":               scopesPartner,
+	"InsertClaim":                  scopesPartner,
+	"InsertLiveCuepoint":           scopesPartner,
+	"InsertManualClaim":            scopesPartner,
+	"InsertPackage":                scopesPartner,
+	"InsertPolicy":                 scopesPartner,
+	"InsertReference":              scopesPartner,
+	"InsertWhitelist":              scopesPartner,
+	"ListAssetLabels":              scopesPartner,
+	"ListAssetRelationships":       scopesPartner,
+	"ListAssetShares":              scopesPartner,
+	"ListAssetSharesIter":          scopesPartner,
+	"ListAssetSharesPages":         scopesPartner,
+	"ListAssets":                   scopesPartner,
+	"ListCampaigns":                scopesPartner,
+	"ListClaims":                   scopesPartner,
+	"ListClaimsIter":               scopesPartner,
+	"ListClaimsPages":              scopesPartner,
+	"ListContentOwners":            scopesContentOwnerRead,
+	"ListMetadataHistory":          scopesPartner,
+	"ListMusicChangeRequests":      scopesPartner,
+	"ListMusicChangeRequestsIter":  scopesPartner,
+	"ListMusicChangeRequestsPages": scopesPartner,
+	"ListMusicReleases":            scopesPartner,
+	"ListMusicReleasesIter":        scopesPartner,
+	"ListMusicReleasesPages":       scopesPartner,
+	"ListMusicTracks":              scopesPartner,
+	"ListMusicTracksIter":          scopesPartner,
+	"ListMusicTracksPages":         scopesPartner,
+	"ListOwnershipHistory":         scopesPartner,
+	"ListPolicies":                 scopesPartner,
+	"ListReferenceConflicts":       scopesPartner,
+	"ListReferenceConflictsIter":   scopesPartner,
+	"ListReferenceConflictsPages":  scopesPartner,
+	"ListReferences":               scopesPartner,
+	"ListReferencesIter":           scopesPartner,
+	"ListReferencesPages":          scopesPartner,
+	"ListSpreadsheetTemplates":     scopesPartner,
+	"ListUploaders":                scopesPartner,
+	"ListWhitelists":               scopesPartner,
+	"ListWhitelistsIter":           scopesPartner,
+	"ListWhitelistsPages":          scopesPartner,
+	"PatchAsset":                   scopesPartner,
+	"PatchAssetMatchPolicy":        scopesPartner,
+	"PatchCampaign":                scopesPartner,
+	"PatchClaim":                   scopesPartner,
+	"PatchOwnership":               scopesPartner,
+	"PatchPolicy":                  scopesPartner,
+	"PatchReference":               scopesPartner,
+	"PatchVideoAdvertisingOption":  scopesPartner,
+	"SearchAssets":                 scopesPartner,
+	"SearchAssetsIter":             scopesPartner,
+	"SearchAssetsPages":            scopesPartner,
+	"SearchClaims":                 scopesPartner,
+	"SearchClaimsIter":             scopesPartner,
+	"SearchClaimsPages":            scopesPartner,
+	"SearchClaimsSharded":          scopesPartner,
+	"UpdateAsset":                  scopesPartner,
+	"UpdateAssetMatchPolicy":       scopesPartner,
+	"UpdateCampaign":               scopesPartner,
+	"UpdateClaim":                  scopesPartner,
+	"UpdateOwnership":              scopesPartner,
+	"UpdatePolicy":                 scopesPartner,
+	"UpdateReference":              scopesPartner,
+	"UpdateVideoAdvertisingOption": scopesPartner,
+	"Validate":                     scopesPartner,
+	"ValidateAsync":                scopesPartner,
+	"ValidateAsyncStatus":          scopesPartner,
+}
+
+// ScopeError is returned by CheckScopes when none of the scopes allowing an endpoint were granted.
+type ScopeError struct {
+	Endpoint string
+	Granted  []Scope
+	Accepted []Scope
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("%s requires one of the scopes [%s]; granted scopes are [%s]",
+		e.Endpoint, joinScopes(e.Accepted), joinScopes(e.Granted))
+}
+
+// RequiredScopes returns the scopes which allow the endpoint, any one of which is sufficient. The endpoint is the
+// name of the library function, e.g. "SearchClaims".
+func RequiredScopes(endpoint string) ([]Scope, error) {
+	scopes, ok := EndpointScopes[endpoint]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEndpoint, endpoint)
+	}
+	return scopes, nil
+}
+
+// CheckScopes verifies, before making a call, that the granted scopes allow the endpoint. It returns a *ScopeError
+// describing the accepted scopes if they do not.
+func CheckScopes(endpoint string, granted []Scope) error {
+	accepted, err := RequiredScopes(endpoint)
+	if err != nil {
+		return err
+	}
+	if len(MissingScopes(granted, accepted)) < len(accepted) {
+		return nil
+	}
+	return &ScopeError{
+		Endpoint: endpoint,
+		Granted:  granted,
+		Accepted: accepted,
+	}
+}
+
+// CheckScopes verifies that the token's granted scopes allow each of the endpoints. See CheckScopes.
+func (t *Token) CheckScopes(endpoints ...string) error {
+	granted := t.Scopes()
+	for _, e := range endpoints {
+		if err := CheckScopes(e, granted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func joinScopes(scopes []Scope) string {
+	s := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		s = append(s, string(scope))
+	}
+	return strings.Join(s, " ")
+}
@@ -0,0 +1,86 @@
+package youtube
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckScopes(t *testing.T) {
+	cases := []struct {
+		name     string
+		endpoint string
+		granted  []Scope
+		err      string
+	}{
+		{name: "granted", endpoint: "SearchClaims", granted: []Scope{ScopePartner}},
+		{name: "any accepted scope", endpoint: "ListVideos", granted: []Scope{ScopeUserEmail, ScopeReadOnly}},
+		{name: "read only owner", endpoint: "ListContentOwners", granted: []Scope{ScopePartnerContentOwnerReadOnly}},
+		{
+			name:     "missing",
+			endpoint: "SearchClaims",
+			granted:  []Scope{ScopeReadOnly},
+			err:      "SearchClaims requires one of the scopes [" + string(ScopePartner) + "]; granted scopes are [" + string(ScopeReadOnly) + "]",
+		},
+		{
+			name:     "none granted",
+			endpoint: "MyChannel",
+			err: "MyChannel requires one of the scopes [" + string(ScopeReadOnly) + " " + string(ScopeAccount) + " " +
+				string(ScopeForceSSL) + "]; granted scopes are []",
+		},
+		{name: "unknown", endpoint: "Nope", granted: []Scope{ScopePartner}, err: "unknown endpoint: Nope"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := CheckScopes(c.endpoint, c.granted)
+			if c.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, c.err)
+		})
+	}
+
+	var se *ScopeError
+	require.ErrorAs(t, CheckScopes("PatchClaim", nil), &se)
+	require.Equal(t, "PatchClaim", se.Endpoint)
+	require.Equal(t, []Scope{ScopePartner}, se.Accepted)
+	require.ErrorIs(t, CheckScopes("Nope", nil), ErrUnknownEndpoint)
+
+	tok := &Token{Scope: string(ScopePartner)}
+	require.NoError(t, tok.CheckScopes("SearchClaims", "ListVideos"))
+	require.ErrorAs(t, tok.CheckScopes("SearchClaims", "MyChannel"), &se)
+	require.Equal(t, "MyChannel", se.Endpoint)
+}
+
+// TestEndpointScopesComplete checks that every exported, non-generic function taking a RequestRunner is registered.
+func TestEndpointScopesComplete(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		require.NoError(t, err)
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Type.TypeParams != nil || !fn.Name.IsExported() {
+				continue
+			}
+			for _, p := range fn.Type.Params.List {
+				if id, ok := p.Type.(*ast.Ident); ok && id.Name == "RequestRunner" {
+					_, ok := EndpointScopes[fn.Name.Name]
+					require.True(t, ok, "%s in %s is missing from EndpointScopes", fn.Name.Name, name)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package youtube
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidCiphertext   = errors.New("invalid ciphertext")
+	ErrMissingRefresh      = errors.New("missing refresh function")
+	ErrMissingRefreshToken = errors.New("missing refresh token")
+	ErrMissingTokenStore   = errors.New("missing token store")
+)
+
+// TokenStore persists Tokens keyed by an application defined key, such as a user or channel id. Get returns
+// ErrNotFound if no token is stored under the key.
+type TokenStore interface {
+	Get(key string) (*Token, error)
+	Put(key string, t *Token) error
+	Delete(key string) error
+}
+
+// MemoryTokenStore is an in-memory TokenStore. The zero value is ready to use.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]Token
+}
+
+func (s *MemoryTokenStore) Get(key string) (*Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tokens[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &t, nil
+}
+
+func (s *MemoryTokenStore) Put(key string, t *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tokens == nil {
+		s.tokens = make(map[string]Token)
+	}
+	s.tokens[key] = *t
+	return nil
+}
+
+func (s *MemoryTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
+
+// FileTokenStore is a TokenStore which keeps one file per key in Dir. Tokens are encrypted at rest with AES-GCM
+// under Key, which must be 16, 24 or 32 bytes long. File names are derived from a hash of the key so that keys are
+// not disclosed on disk.
+type FileTokenStore struct {
+	Dir string
+	Key []byte
+
+	mu sync.Mutex
+}
+
+func (s *FileTokenStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".token")
+}
+
+func (s *FileTokenStore) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.Key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *FileTokenStore) Get(key string) (*Token, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	aead, err := s.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	// The key is used as additional data so that a file cannot be swapped for the file of another key.
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	var t Token
+	if err := json.Unmarshal(plain, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *FileTokenStore) Put(key string, t *Token) error {
+	plain, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	aead, err := s.aead()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := aead.Seal(nonce, nonce, plain, []byte(key))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(s.path(key), writeBytes(data))
+}
+
+func (s *FileTokenStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// TokenRefresher returns valid access tokens from a TokenStore, refreshing and persisting them when they are about to
+// expire. Refreshes are serialized per key, so two goroutines never redeem the same refresh token at once.
+//
+// e.g.,
+//
+//	refresher := &TokenRefresher{
+//	    Store: store,
+//	    Refresh: func(refreshToken string) (*Token, error) {
+//	        return RefreshAuthToken(clientId, clientSecret, refreshToken, timeout)
+//	    },
+//	}
+type TokenRefresher struct {
+	Store TokenStore
+
+	// Refresh exchanges a refresh token for a new Token. See RefreshAuthToken.
+	Refresh func(refreshToken string) (*Token, error)
+
+	// ExpiryWindow is how long before its expiry a token is refreshed. Defaults to one minute.
+	ExpiryWindow time.Duration
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// Token returns a valid token for the key, refreshing it first if required. A token with ExpiresInSecs but no Expiry,
+// such as one saved before Expiry was recorded, is refreshed since its age is unknown. If a refresh is required but the
+// stored token has no refresh token, ErrMissingRefreshToken is returned.
+func (r *TokenRefresher) Token(key string) (*Token, error) {
+	if r.Store == nil {
+		return nil, ErrMissingTokenStore
+	}
+	if r.Refresh == nil {
+		return nil, ErrMissingRefresh
+	}
+	unlock := r.lock(key)
+	defer unlock()
+
+	t, err := r.Store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	window := r.ExpiryWindow
+	if window <= 0 {
+		window = time.Minute
+	}
+	unknownExpiry := t.Expiry.IsZero() && t.ExpiresInSecs > 0
+	if t.Valid() && !unknownExpiry && !t.ExpiresWithin(window) {
+		return t, nil
+	}
+	if t.RefreshToken == "" {
+		return nil, ErrMissingRefreshToken
+	}
+
+	refreshed, err := r.Refresh(t.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = t.RefreshToken
+	}
+	if err := r.Store.Put(key, refreshed); err != nil {
+		return nil, err
+	}
+	return refreshed, nil
+}
+
+// lock acquires the lock for the key and returns a function releasing it.
+func (r *TokenRefresher) lock(key string) func() {
+	r.mu.Lock()
+	if r.locks == nil {
+		r.locks = make(map[string]*keyLock)
+	}
+	l, ok := r.locks[key]
+	if !ok {
+		l = &keyLock{}
+		r.locks[key] = l
+	}
+	l.refs++
+	r.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		r.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(r.locks, key)
+		}
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,121 @@
+package youtube
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	dir := t.TempDir()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	store := &FileTokenStore{Dir: dir, Key: key}
+
+	_, err := store.Get("alice")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	tok := &Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour).UTC().Truncate(time.Second)}
+	require.NoError(t, store.Put("alice", tok))
+	require.NoError(t, store.Put("bob", &Token{AccessToken: "bob"}))
+
+	got, err := store.Get("alice")
+	require.NoError(t, err)
+	require.Equal(t, tok, got)
+
+	_, err = (&FileTokenStore{Dir: dir, Key: []byte("fedcba9876543210fedcba9876543210")}).Get("alice")
+	require.ErrorIs(t, err, ErrInvalidCiphertext, "wrong key")
+
+	// The key is bound as additional data, so bob's file cannot stand in for alice's.
+	data, err := os.ReadFile(store.path("bob"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(store.path("alice"), data, 0600))
+	_, err = store.Get("alice")
+	require.ErrorIs(t, err, ErrInvalidCiphertext, "swapped file")
+
+	require.NoError(t, store.Delete("alice"))
+	_, err = store.Get("alice")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestTokenRefresherSerializesPerKey(t *testing.T) {
+	store := &MemoryTokenStore{}
+	for _, key := range []string{"alice", "bob"} {
+		require.NoError(t, store.Put(key, &Token{AccessToken: "old", RefreshToken: "r-" + key, Expiry: time.Now().Add(-time.Minute)}))
+	}
+
+	var calls, inFlight, maxInFlight atomic.Int32
+	r := &TokenRefresher{
+		Store: store,
+		Refresh: func(refreshToken string) (*Token, error) {
+			calls.Add(1)
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return &Token{AccessToken: "new-" + refreshToken, Expiry: time.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	toks := make([]*Token, 10)
+	errs := make([]error, 10)
+	for i := range toks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			toks[i], errs[i] = r.Token("alice")
+		}()
+	}
+	wg.Wait()
+	for i := range toks {
+		require.NoError(t, errs[i])
+		require.Equal(t, "new-r-alice", toks[i].AccessToken)
+	}
+	require.EqualValues(t, 1, calls.Load(), "the refresh token should be redeemed once")
+	require.EqualValues(t, 1, maxInFlight.Load())
+
+	tok, err := r.Token("bob")
+	require.NoError(t, err)
+	require.Equal(t, "new-r-bob", tok.AccessToken)
+	require.Equal(t, "r-bob", tok.RefreshToken, "the refresh token should be kept")
+	require.Empty(t, r.locks, "locks should be released")
+}
+
+func TestTokenRefresherExpiry(t *testing.T) {
+	var refreshed []string
+	r := &TokenRefresher{
+		Store: &MemoryTokenStore{},
+		Refresh: func(refreshToken string) (*Token, error) {
+			refreshed = append(refreshed, refreshToken)
+			return &Token{AccessToken: "new", ExpiresInSecs: 3600, Expiry: time.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	// A token saved before Expiry was recorded has an unknown age, so it is refreshed.
+	require.NoError(t, r.Store.Put("legacy", &Token{AccessToken: "old", RefreshToken: "r-legacy", ExpiresInSecs: 3600}))
+	tok, err := r.Token("legacy")
+	require.NoError(t, err)
+	require.Equal(t, "new", tok.AccessToken)
+	require.Equal(t, []string{"r-legacy"}, refreshed)
+
+	// Without expires_in, a token without an Expiry is used as is.
+	require.NoError(t, r.Store.Put("static", &Token{AccessToken: "static"}))
+	tok, err = r.Token("static")
+	require.NoError(t, err)
+	require.Equal(t, "static", tok.AccessToken)
+
+	// An expired token without a refresh token cannot be refreshed.
+	require.NoError(t, r.Store.Put("expired", &Token{AccessToken: "old", Expiry: time.Now().Add(-time.Minute)}))
+	_, err = r.Token("expired")
+	require.ErrorIs(t, err, ErrMissingRefreshToken)
+	require.Len(t, refreshed, 1)
+}
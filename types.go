@@ -192,5 +192,11 @@ type ValidateError struct {
 	Severity    string `json:"severity,omitempty"`
 }
 
+// Ptr returns a pointer to v. It is useful to set optional fields which track
+// presence, e.g. PatchClaimsParams.BlockOutsideOwnership.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
 // Empty is the response for delete operations.
 type Empty struct{}
@@ -1,9 +1,17 @@
 package youtube
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidVideoDuration = errors.New("invalid video duration")
 )
 
 const (
@@ -49,6 +57,60 @@ type Video struct {
 	// Snippet: The snippet object contains basic details about the video,
 	// such as its title, description, and category.
 	Snippet *VideoSnippet
+
+	// ContentDetails: The contentDetails object contains information about
+	// the video content, including the length of the video.
+	ContentDetails *VideoContentDetails `json:"contentDetails,omitempty"`
+}
+
+// VideoContentDetails - the contentDetails object contains information about the video content, including the length
+// of the video and an indication of whether captions are available for the video.
+//
+// see https://developers.google.com/youtube/v3/docs/videos#contentDetails
+type VideoContentDetails struct {
+	// Caption: Indicates whether captions are available for the video.
+	Caption string `json:"caption,omitempty"`
+
+	// Definition: Indicates whether the video is available in high
+	// definition (hd) or only in standard definition (sd).
+	Definition string `json:"definition,omitempty"`
+
+	// Dimension: Indicates whether the video is available in 3D or in 2D.
+	Dimension string `json:"dimension,omitempty"`
+
+	// Duration: The length of the video, as an ISO 8601 duration such as
+	// PT4M13S. Use ParsedDuration to read it.
+	Duration string `json:"duration,omitempty"`
+
+	// LicensedContent: Indicates whether the video represents licensed
+	// content, which means that the content was uploaded to a channel linked
+	// to a YouTube content partner and then claimed by that partner.
+	LicensedContent bool `json:"licensedContent,omitempty"`
+
+	// Projection: Specifies the projection format of the video.
+	Projection string `json:"projection,omitempty"`
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParsedDuration parses the ISO 8601 duration of the video.
+func (d *VideoContentDetails) ParsedDuration() (time.Duration, error) {
+	m := isoDurationRegexp.FindStringSubmatch(d.Duration)
+	if m == nil || d.Duration == "P" || strings.HasSuffix(d.Duration, "T") {
+		return 0, ErrInvalidVideoDuration
+	}
+	var out time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(m[i+1], 64)
+		if err != nil {
+			return 0, ErrInvalidVideoDuration
+		}
+		out += time.Duration(v * float64(unit))
+	}
+	return out, nil
 }
 
 type ListVideosResponse struct {
@@ -139,6 +201,11 @@ func (o *ListVideoParams) Values() url.Values {
 	return vals
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (o *ListVideoParams) SetPageToken(token string) {
+	o.PageToken = token
+}
+
 // ListVideos provides a list of videos to the user.
 //
 // This function has a quota cost of 1 unit.
@@ -190,6 +190,11 @@ func (p *ListWhitelistsParams) Values() url.Values {
 	return vals
 }
 
+// SetPageToken sets the page token, implementing PageTokenSetter.
+func (p *ListWhitelistsParams) SetPageToken(token string) {
+	p.PageToken = token
+}
+
 // ListWhitelists retrieves a list of whitelisted YouTube channels for the
 // content owner. Whitelisted channels are exempt from having claims placed
 // on their uploaded videos by the content owner's assets.